@@ -0,0 +1,221 @@
+package gospinner
+
+//go:generate go run ./internal/gensets -in sets.json -out sets.go
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// AnimationKind represents the kind of the animation
+type AnimationKind int
+
+// Animation represents an animation with frames and speed (recommended)
+type Animation struct {
+	interval time.Duration
+	frames   []string
+
+	// width is the display width of all the frames
+	width int
+
+	// fallback is the animation used when the terminal doesn't support
+	// unicode
+	fallback *Animation
+}
+
+// Alignment is the alignment of the frames narrower than the widest one
+type Alignment int
+
+const (
+	// AlignLeft pads the frames on the right.
+	AlignLeft Alignment = iota
+	// AlignRight pads the frames on the left.
+	AlignRight
+	// AlignCenter pads the frames on both sides.
+	AlignCenter
+)
+
+// AnimationOption customizes an animation on its creation.
+type AnimationOption func(a *animationOptions)
+
+// animationOptions are the options of an animation creation
+type animationOptions struct {
+	align    Alignment
+	fallback *Animation
+}
+
+// WithAlignment sets how the frames are padded to the width of the widest one,
+// by default AlignLeft.
+func WithAlignment(align Alignment) AnimationOption {
+	return func(o *animationOptions) {
+		o.align = align
+	}
+}
+
+// WithASCIIFallback sets the animation used when the locale is not UTF-8, its
+// frames need to be ASCII. By default the animations that aren't ASCII fall
+// back to Slash.
+func WithASCIIFallback(fallback Animation) AnimationOption {
+	return func(o *animationOptions) {
+		o.fallback = &fallback
+	}
+}
+
+// NewAnimation creates a new custom animation with its frames and the
+// recommended interval between them. The frames are padded to the same
+// display width so the message doesn't move while animating.
+func NewAnimation(frames []string, interval time.Duration, opts ...AnimationOption) (Animation, error) {
+	o := animationOptions{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	an := Animation{interval: interval, fallback: o.fallback}
+	an.frames, an.width = normalizeFrames(frames, o.align)
+	if err := an.validate(); err != nil {
+		return Animation{}, err
+	}
+	if an.fallback != nil {
+		if err := an.fallback.validate(); err != nil {
+			return Animation{}, err
+		}
+		if !an.fallback.isASCII() {
+			return Animation{}, errors.New("the ASCII fallback has frames that aren't ASCII")
+		}
+	}
+	return an, nil
+}
+
+// isASCII returns true if all the frames are ASCII
+func (a Animation) isASCII() bool {
+	for _, f := range a.frames {
+		if !isASCII(stripMarkup(f)) {
+			return false
+		}
+	}
+	return true
+}
+
+// asciiFallback returns the animation to use when the locale is not UTF-8
+func (a Animation) asciiFallback() Animation {
+	switch {
+	case a.fallback != nil:
+		return *a.fallback
+	case a.isASCII():
+		return a
+	}
+	return defaultASCIIFallback
+}
+
+// Width returns the display width of the frames in cells.
+func (a Animation) Width() int {
+	return a.width
+}
+
+// normalizeFrames pads the frames to the display width of the widest one and
+// returns them with the width
+func normalizeFrames(frames []string, align Alignment) ([]string, int) {
+	width := 0
+	for _, f := range frames {
+		if w := displayWidth(stripMarkup(f)); w > width {
+			width = w
+		}
+	}
+
+	res := make([]string, len(frames))
+	for i, f := range frames {
+		pad := width - displayWidth(stripMarkup(f))
+		switch align {
+		case AlignRight:
+			res[i] = strings.Repeat(" ", pad) + f
+		case AlignCenter:
+			res[i] = strings.Repeat(" ", pad/2) + f + strings.Repeat(" ", pad-pad/2)
+		default:
+			res[i] = f + strings.Repeat(" ", pad)
+		}
+	}
+	return res, width
+}
+
+// Frames returns the frames of the animation.
+func (a Animation) Frames() []string {
+	return append([]string{}, a.frames...)
+}
+
+// Interval returns the recommended interval between the frames.
+func (a Animation) Interval() time.Duration {
+	return a.interval
+}
+
+// validate returns an error if the animation can't be animated
+func (a Animation) validate() error {
+	if len(a.frames) == 0 {
+		return errors.New("animation needs at least one frame")
+	}
+	if a.interval <= 0 {
+		return errors.New("animation interval needs to be greater than zero")
+	}
+	return nil
+}
+
+// registry of the animations by name, the built-in ones and the custom ones
+var registry = struct {
+	kinds    map[string]AnimationKind
+	names    map[AnimationKind]string
+	nextKind AnimationKind
+	sync.RWMutex
+}{
+	kinds:    map[string]AnimationKind{},
+	names:    map[AnimationKind]string{},
+	nextKind: AnimationKind(len(animations)),
+}
+
+// defaultASCIIFallback is the built-in Slash animation, it's captured on init
+// so registering another "slash" animation doesn't change it
+var defaultASCIIFallback Animation
+
+func init() {
+	for kind, an := range animations {
+		an.frames, an.width = normalizeFrames(an.frames, AlignLeft)
+		animations[kind] = an
+	}
+	defaultASCIIFallback = animations[Slash]
+	for kind, name := range builtinNames {
+		registry.kinds[normalizeName(name)] = kind
+		registry.names[kind] = name
+	}
+}
+
+// RegisterAnimation registers a custom animation with a name, the returned kind
+// can be used like the built-in ones. Registering a name again replaces its
+// animation and returns the same kind, this includes the names of the built-in
+// animations. It panics if the animation is not valid, NewAnimation should be
+// used to create it.
+func RegisterAnimation(name string, anim Animation) AnimationKind {
+	if err := anim.validate(); err != nil {
+		panic(fmt.Sprintf("gospinner: invalid animation %q: %s", name, err))
+	}
+
+	registry.Lock()
+	defer registry.Unlock()
+	kind, ok := registry.kinds[normalizeName(name)]
+	if !ok {
+		kind = registry.nextKind
+		registry.nextKind++
+		registry.kinds[normalizeName(name)] = kind
+		registry.names[kind] = name
+	}
+	animations[kind] = anim
+	return kind
+}
+
+// lookupAnimation returns the animation of a kind
+func lookupAnimation(kind AnimationKind) (Animation, bool) {
+	registry.RLock()
+	defer registry.RUnlock()
+	an, ok := animations[kind]
+	return an, ok
+}
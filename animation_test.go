@@ -0,0 +1,154 @@
+package gospinner
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestNewAnimation(t *testing.T) {
+	tests := []struct {
+		frames   []string
+		interval time.Duration
+
+		wantErr bool
+	}{
+		{[]string{"a", "b"}, 100 * time.Millisecond, false},
+		{[]string{"a"}, 1 * time.Millisecond, false},
+		{[]string{}, 100 * time.Millisecond, true},
+		{nil, 100 * time.Millisecond, true},
+		{[]string{"a", "b"}, 0, true},
+		{[]string{"a", "b"}, -1 * time.Second, true},
+	}
+
+	for _, test := range tests {
+		an, err := NewAnimation(test.frames, test.interval)
+		if test.wantErr != (err != nil) {
+			t.Errorf("%+v\n - Wrong error, got: %v", test, err)
+			continue
+		}
+		if err == nil && (!reflect.DeepEqual(an.Frames(), test.frames) || an.Interval() != test.interval) {
+			t.Errorf("%+v\n - Wrong animation, got: %v, %v", test, an.Frames(), an.Interval())
+		}
+	}
+}
+
+func TestRegisterAnimation(t *testing.T) {
+	an, _ := NewAnimation([]string{"a", "b"}, 100*time.Millisecond)
+	kind := RegisterAnimation("test-register", an)
+	if kind <= ProgressBar {
+		t.Errorf("- Custom kind shouldn't be a built-in one, got: %d", kind)
+	}
+
+	an2, _ := NewAnimation([]string{"c", "d"}, 100*time.Millisecond)
+	if got := RegisterAnimation("test-register", an2); got != kind {
+		t.Errorf("- Registering again should return the same kind, got: %d, want: %d", got, kind)
+	}
+	if got := RegisterAnimation("test-register-2", an); got == kind {
+		t.Errorf("- Registering a new name should return a new kind, got: %d", got)
+	}
+
+	var buf bytes.Buffer
+	s, err := NewSpinnerNoColor(kind)
+	if err != nil {
+		t.Fatalf("\n - Creation shouldn't fail, it did: %s", err)
+	}
+	s.Writer = &buf
+	s.separator = "|"
+	s.SetClock(NewFakeClock(time.Now()))
+	s.StartWithSpeed("test", time.Hour)
+	s.Render()
+	s.Render()
+	s.Stop()
+
+	want := "|c test|d test"
+	if got := buf.String(); got != want {
+		t.Errorf("- Wrong result, got: %q, want: %q", got, want)
+	}
+}
+
+func TestRegisterInvalidAnimation(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("\n - Registering an invalid animation should panic, it didn't")
+		}
+	}()
+	RegisterAnimation("test-invalid", Animation{})
+}
+
+func TestNewSpinnerWithAnimation(t *testing.T) {
+	tests := []struct {
+		opts []Option
+
+		want string
+	}{
+		{[]Option{WithoutColor()}, "|a test|b test"},
+		{[]Option{WithColor(FgRed)}, "|\x1b[31ma\x1b[0m test|\x1b[31mb\x1b[0m test"},
+		{nil, "|\x1b[96ma\x1b[0m test|\x1b[96mb\x1b[0m test"},
+	}
+
+	for _, test := range tests {
+		var buf bytes.Buffer
+		an, _ := NewAnimation([]string{"a", "b"}, 100*time.Millisecond)
+		s, err := NewSpinnerWithAnimation(an, append(test.opts, WithWriter(&buf))...)
+		if err != nil {
+			t.Fatalf("%+v\n - Creation shouldn't fail, it did: %s", test, err)
+		}
+		s.separator = "|"
+		s.SetClock(NewFakeClock(time.Now()))
+		s.StartWithSpeed("test", time.Hour)
+		s.Render()
+		s.Render()
+		s.Stop()
+
+		if got := buf.String(); got != test.want {
+			t.Errorf("%+v\n - Wrong result, got: %q, want: %q", test, got, test.want)
+		}
+	}
+
+	if _, err := NewSpinnerWithAnimation(Animation{}); err == nil {
+		t.Errorf("\n - Creation with an invalid animation should fail, it didn't")
+	}
+}
+
+func TestNewAnimationNormalization(t *testing.T) {
+	tests := []struct {
+		frames []string
+		align  Alignment
+
+		want      []string
+		wantWidth int
+	}{
+		{[]string{"a", "bb", "ccc"}, AlignLeft, []string{"a  ", "bb ", "ccc"}, 3},
+		{[]string{"a", "bb", "ccc"}, AlignRight, []string{"  a", " bb", "ccc"}, 3},
+		{[]string{"a", "bb", "cccc"}, AlignCenter, []string{" a  ", " bb ", "cccc"}, 4},
+		{[]string{"測", "a"}, AlignLeft, []string{"測", "a "}, 2},
+		{[]string{"\x1b[1ma\x1b[0m", "bb"}, AlignLeft, []string{"\x1b[1ma\x1b[0m ", "bb"}, 2},
+		{[]string{"◐", "◓"}, AlignLeft, []string{"◐", "◓"}, 1},
+	}
+
+	for _, test := range tests {
+		an, err := NewAnimation(test.frames, time.Second, WithAlignment(test.align))
+		if err != nil {
+			t.Fatalf("%+v\n - Creation shouldn't fail, it did: %s", test, err)
+		}
+		if !reflect.DeepEqual(an.Frames(), test.want) {
+			t.Errorf("%+v\n - Wrong frames, got: %q, want: %q", test, an.Frames(), test.want)
+		}
+		if an.Width() != test.wantWidth {
+			t.Errorf("%+v\n - Wrong width, got: %d, want: %d", test, an.Width(), test.wantWidth)
+		}
+	}
+}
+
+func TestBuiltinAnimationsWidth(t *testing.T) {
+	for _, kind := range Animations() {
+		an, _ := lookupAnimation(kind)
+		for _, f := range an.frames {
+			if displayWidth(f) != an.Width() {
+				t.Errorf("- Frame %q of %s should have the animation width %d", f, kind, an.Width())
+			}
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package gospinner
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+)
+
+const (
+	// ANSI sequences used to render the spinners
+	cursorUpSeq   = "\x1b[%dA"
+	eraseLineSeq  = "\x1b[2K"
+	hideCursorSeq = "\x1b[?25l"
+	showCursorSeq = "\x1b[?25h"
+)
+
+// ClearMode is the way the previous frame is cleaned before rendering the next
+// one
+type ClearMode int
+
+const (
+	// ClearAuto uses ClearANSI when the writer is a terminal that is not dumb
+	// and ClearPadding otherwise.
+	ClearAuto ClearMode = iota
+	// ClearANSI erases the line with ANSI sequences and hides the cursor while
+	// the spinner is running.
+	ClearANSI
+	// ClearPadding writes spaces over the previous frame.
+	ClearPadding
+)
+
+// useANSI returns true if the previous frames will be cleaned with ANSI
+// sequences on the writer
+func useANSI(mode ClearMode, w io.Writer) bool {
+	switch mode {
+	case ClearANSI:
+		return true
+	case ClearPadding:
+		return false
+	}
+	terminal, _ := isTerminal(w)
+	return terminal && os.Getenv("TERM") != "dumb"
+}
+
+// SetClearMode sets how the previous frames are cleaned, by default ClearAuto,
+// it will be used from the next start.
+func (s *Spinner) SetClearMode(mode ClearMode) {
+	s.Lock()
+	s.clearMode = mode
+	s.Unlock()
+}
+
+// SignalMode is the way the spinners handle SIGINT and SIGTERM while the
+// cursor is hidden
+type SignalMode int
+
+const (
+	// SignalRestoreRaise shows the hidden cursors and sends the signal again to
+	// the program so it gets its default behaviour, like exiting.
+	SignalRestoreRaise SignalMode = iota
+	// SignalRestore only shows the hidden cursors, for programs that handle
+	// the signals with signal.Notify or signal.NotifyContext.
+	SignalRestore
+	// SignalIgnore doesn't listen to the signals.
+	SignalIgnore
+)
+
+// hiddenCursors tracks the spinners that have hidden the cursor so it can be
+// restored if the program is interrupted
+var hiddenCursors = struct {
+	spinners map[*Spinner]struct{}
+	signals  chan os.Signal
+	mode     SignalMode
+	sync.Mutex
+}{
+	spinners: map[*Spinner]struct{}{},
+}
+
+// SetSignalMode sets how the spinners handle SIGINT and SIGTERM while they
+// hide the cursor, by default SignalRestoreRaise. Programs with their own
+// signal handling should use SignalRestore, so they don't receive the signal
+// twice, or SignalIgnore. It will be used from the next start.
+func SetSignalMode(mode SignalMode) {
+	hiddenCursors.Lock()
+	hiddenCursors.mode = mode
+	hiddenCursors.Unlock()
+}
+
+// hideCursor hides the cursor of the spinner writer, the lock needs to be held
+func (s *Spinner) hideCursor() {
+	fmt.Fprint(s.Writer, hideCursorSeq)
+
+	hiddenCursors.Lock()
+	defer hiddenCursors.Unlock()
+	hiddenCursors.spinners[s] = struct{}{}
+	if hiddenCursors.signals == nil && hiddenCursors.mode != SignalIgnore {
+		hiddenCursors.signals = make(chan os.Signal, 1)
+		signal.Notify(hiddenCursors.signals, os.Interrupt, syscall.SIGTERM)
+		go restoreCursorsOnSignal(hiddenCursors.signals, hiddenCursors.mode == SignalRestoreRaise)
+	}
+}
+
+// showCursor shows the cursor of the spinner writer, the lock needs to be held
+func (s *Spinner) showCursor() {
+	fmt.Fprint(s.Writer, showCursorSeq)
+
+	hiddenCursors.Lock()
+	defer hiddenCursors.Unlock()
+	delete(hiddenCursors.spinners, s)
+	if len(hiddenCursors.spinners) == 0 && hiddenCursors.signals != nil {
+		signal.Stop(hiddenCursors.signals)
+		close(hiddenCursors.signals)
+		hiddenCursors.signals = nil
+	}
+}
+
+// restoreCursorsOnSignal waits for an interruption, shows the hidden cursors
+// and if raise is set raises the signal again so the program gets its default
+// behaviour.
+func restoreCursorsOnSignal(signals chan os.Signal, raise bool) {
+	sig, ok := <-signals
+	if !ok {
+		return
+	}
+
+	hiddenCursors.Lock()
+	spinners := make([]*Spinner, 0, len(hiddenCursors.spinners))
+	for s := range hiddenCursors.spinners {
+		spinners = append(spinners, s)
+	}
+	hiddenCursors.spinners = map[*Spinner]struct{}{}
+	signal.Stop(signals)
+	if hiddenCursors.signals == signals {
+		hiddenCursors.signals = nil
+	}
+	hiddenCursors.Unlock()
+
+	for _, s := range spinners {
+		s.Lock()
+		fmt.Fprint(s.Writer, showCursorSeq)
+		s.Unlock()
+	}
+
+	if !raise {
+		return
+	}
+	if p, err := os.FindProcess(os.Getpid()); err == nil {
+		p.Signal(sig)
+	}
+}
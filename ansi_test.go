@@ -0,0 +1,141 @@
+package gospinner
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestUseANSI(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("\n - Pipe creation shouldn't fail, it did: %s", err)
+	}
+	defer r.Close()
+	defer w.Close()
+
+	tests := []struct {
+		mode   ClearMode
+		writer io.Writer
+
+		want bool
+	}{
+		{ClearAuto, w, false},
+		{ClearAuto, &bytes.Buffer{}, false},
+		{ClearANSI, &bytes.Buffer{}, true},
+		{ClearPadding, w, false},
+	}
+
+	for _, test := range tests {
+		if got := useANSI(test.mode, test.writer); got != test.want {
+			t.Errorf("%+v\n - Wrong ANSI mode, got: %v, want: %v", test, got, test.want)
+		}
+	}
+}
+
+func TestRenderANSI(t *testing.T) {
+	tests := []struct {
+		finisher Finisher
+
+		want string
+	}{
+		{(*Spinner).Stop, "\x1b[?25l\r\x1b[2K- long test\r\x1b[2K\\ test\x1b[?25h"},
+		{(*Spinner).Succeed, "\x1b[?25l\r\x1b[2K- long test\r\x1b[2K\\ test\x1b[?25h\r\x1b[2K✔ test\n"},
+		{(*Spinner).Finish, "\x1b[?25l\r\x1b[2K- long test\r\x1b[2K\\ test\x1b[?25h\n"},
+	}
+
+	for _, test := range tests {
+		var buf bytes.Buffer
+		s, _ := NewSpinnerNoColor(Slash)
+		s.Writer = &buf
+		s.SetClearMode(ClearANSI)
+		s.SetClock(NewFakeClock(time.Now()))
+
+		s.StartWithSpeed("long test", time.Hour)
+		s.Render()
+		s.SetMessage("test")
+		s.Render()
+		test.finisher(s)
+
+		if got := buf.String(); got != test.want {
+			t.Errorf("%+v\n - Wrong result, got: %q, want: %q", test, got, test.want)
+		}
+
+		hiddenCursors.Lock()
+		_, hidden := hiddenCursors.spinners[s]
+		hiddenCursors.Unlock()
+		if hidden {
+			t.Errorf("%+v\n - Spinner cursor should be restored, it isn't", test)
+		}
+	}
+}
+
+func TestPrintANSI(t *testing.T) {
+	want := "\x1b[?25l\r\x1b[2K- test\r\x1b[2Klog\n\r- test\x1b[?25h"
+
+	var buf bytes.Buffer
+	s, _ := NewSpinnerNoColor(Slash)
+	s.Writer = &buf
+	s.SetClearMode(ClearANSI)
+	s.SetClock(NewFakeClock(time.Now()))
+
+	s.StartWithSpeed("test", time.Hour)
+	s.Render()
+	s.Println("log")
+	s.Stop()
+
+	if got := buf.String(); got != want {
+		t.Errorf("- Wrong result, got: %q, want: %q", got, want)
+	}
+}
+
+func TestSignalModeRestore(t *testing.T) {
+	p, err := os.FindProcess(os.Getpid())
+	if err != nil {
+		t.Skipf("can't find the test process: %s", err)
+	}
+
+	// The program handles the interruptions by itself.
+	appSignals := make(chan os.Signal, 2)
+	signal.Notify(appSignals, os.Interrupt)
+	defer signal.Stop(appSignals)
+	SetSignalMode(SignalRestore)
+	defer SetSignalMode(SignalRestoreRaise)
+
+	var buf bytes.Buffer
+	s, _ := NewSpinnerNoColor(Slash)
+	s.Writer = &buf
+	s.SetClearMode(ClearANSI)
+	s.SetClock(NewFakeClock(time.Now()))
+	s.StartWithSpeed("test", time.Hour)
+	defer s.Stop()
+
+	if err := p.Signal(os.Interrupt); err != nil {
+		t.Skipf("can't interrupt the test process: %s", err)
+	}
+	<-appSignals
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		s.Lock()
+		restored := strings.HasSuffix(buf.String(), showCursorSeq)
+		s.Unlock()
+		if restored {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("\n - The cursor should be restored")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	select {
+	case <-appSignals:
+		t.Errorf("\n - The signal shouldn't be sent again")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
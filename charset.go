@@ -0,0 +1,76 @@
+package gospinner
+
+import (
+	"os"
+	"strings"
+	"unicode/utf8"
+)
+
+// CharsetMode is the way the spinner decides if it uses only ASCII characters
+type CharsetMode int
+
+const (
+	// CharsetAuto uses ASCII when the locale of LC_ALL, LC_CTYPE or LANG is
+	// not UTF-8, like LANG=C. Without locale UTF-8 is assumed.
+	CharsetAuto CharsetMode = iota
+	// CharsetUnicode always uses the animation and the symbols as they are.
+	CharsetUnicode
+	// CharsetASCII always uses the ASCII fallbacks.
+	CharsetASCII
+)
+
+// The ASCII replacements of the default ellipsis and progress bar
+const (
+	asciiEllipsis = "..."
+	asciiFilled   = "#"
+	asciiEmpty    = "-"
+)
+
+// utf8Locale returns true if the locale of the environment is UTF-8, the
+// first variable set of LC_ALL, LC_CTYPE and LANG is used
+func utf8Locale() bool {
+	for _, name := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		if v := strings.ToLower(os.Getenv(name)); v != "" {
+			return strings.Contains(v, "utf-8") || strings.Contains(v, "utf8")
+		}
+	}
+	return true
+}
+
+// useASCII returns true if the spinner will use ASCII with the charset mode
+func useASCII(mode CharsetMode) bool {
+	switch mode {
+	case CharsetUnicode:
+		return false
+	case CharsetASCII:
+		return true
+	}
+	return !utf8Locale()
+}
+
+// isASCII returns true if the text only has ASCII characters
+func isASCII(text string) bool {
+	for i := 0; i < len(text); i++ {
+		if text[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
+
+// SetCharsetMode sets if the spinner only uses ASCII characters, by default
+// CharsetAuto, it will be used from the next start.
+func (s *Spinner) SetCharsetMode(mode CharsetMode) {
+	s.Lock()
+	s.charsetMode = mode
+	s.Unlock()
+}
+
+// animationFrames returns the frames of the animation or of its ASCII
+// fallback, the lock needs to be held
+func (s *Spinner) animationFrames() []string {
+	if s.ascii {
+		return s.animation.asciiFallback().frames
+	}
+	return s.animation.frames
+}
@@ -0,0 +1,151 @@
+package gospinner
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestUTF8Locale(t *testing.T) {
+	tests := []struct {
+		lcAll, lcCtype, lang string
+
+		want bool
+	}{
+		{"", "", "", true},
+		{"", "", "en_US.UTF-8", true},
+		{"", "", "C.utf8", true},
+		{"", "", "C", false},
+		{"", "", "POSIX", false},
+		{"", "en_US.UTF-8", "C", true},
+		{"C", "en_US.UTF-8", "en_US.UTF-8", false},
+	}
+
+	for _, test := range tests {
+		t.Setenv("LC_ALL", test.lcAll)
+		t.Setenv("LC_CTYPE", test.lcCtype)
+		t.Setenv("LANG", test.lang)
+		if got := utf8Locale(); got != test.want {
+			t.Errorf("%+v\n - Wrong UTF-8 detection, got: %t, want: %t", test, got, test.want)
+		}
+	}
+}
+
+func TestASCIIFallback(t *testing.T) {
+	custom, _ := NewAnimation([]string{"◐", "◓"}, time.Second)
+	fallback, _ := NewAnimation([]string{"o", "O"}, time.Second)
+	withFallback, _ := NewAnimation([]string{"◐", "◓"}, time.Second, WithASCIIFallback(fallback))
+	simpleDots, _ := lookupAnimation(SimpleDots)
+	dots, _ := lookupAnimation(Dots)
+
+	tests := []struct {
+		name string
+		an   Animation
+
+		want []string
+	}{
+		{"builtin", dots, []string{"-", "\\", "|", "/"}},
+		{"ascii builtin", simpleDots, simpleDots.frames},
+		{"custom", custom, []string{"-", "\\", "|", "/"}},
+		{"custom with fallback", withFallback, []string{"o", "O"}},
+	}
+
+	for _, test := range tests {
+		t.Setenv("LANG", "C")
+		t.Setenv("LC_ALL", "")
+		t.Setenv("LC_CTYPE", "")
+		var b bytes.Buffer
+		s, _ := NewSpinnerWithAnimation(test.an, WithWriter(&b), WithoutColor())
+		s.Start("test")
+		s.Stop()
+		if len(s.symbols) != len(test.want) {
+			t.Errorf("%s\n - Wrong fallback frames, got: %q, want: %q", test.name, s.symbols, test.want)
+			continue
+		}
+		for i := range test.want {
+			if s.symbols[i] != test.want[i] {
+				t.Errorf("%s\n - Wrong fallback frame, got: %q, want: %q", test.name, s.symbols[i], test.want[i])
+			}
+		}
+	}
+
+	if _, err := NewAnimation([]string{"a"}, time.Second, WithASCIIFallback(custom)); err == nil {
+		t.Errorf("\n - A fallback that isn't ASCII should fail, it didn't")
+	}
+}
+
+func TestASCIIFinish(t *testing.T) {
+	tests := []struct {
+		mode CharsetMode
+		lang string
+
+		want string
+	}{
+		{CharsetAuto, "C", "\rv test...\n"},
+		{CharsetAuto, "en_US.UTF-8", "\r✔ testin…\n"},
+		{CharsetASCII, "en_US.UTF-8", "\rv test...\n"},
+		{CharsetUnicode, "C", "\r✔ testin…\n"},
+	}
+
+	for _, test := range tests {
+		t.Setenv("LANG", test.lang)
+		t.Setenv("LC_ALL", "")
+		t.Setenv("LC_CTYPE", "")
+		var b bytes.Buffer
+		s, _ := NewSpinner(Dots)
+		s.Writer = &b
+		s.SetColorMode(ColorNever)
+		s.SetClearMode(ClearPadding)
+		s.SetCharsetMode(test.mode)
+		s.SetWidth(10)
+		s.Start("testing ascii")
+		s.Succeed()
+		if got := b.String(); !strings.HasSuffix(got, test.want) {
+			t.Errorf("%+v\n - Wrong finish, got: %q, want suffix: %q", test, got, test.want)
+		}
+	}
+}
+
+func TestASCIIProgressBar(t *testing.T) {
+	s, _ := NewSpinner(Dots)
+	s.ascii = true
+	s.SetBar(Bar{Width: 4, Filled: "█", Empty: "▒"})
+	s.SetProgress(1, 2)
+	s.disableColor = true
+	s.Lock()
+	bar, _ := s.progressParts(time.Now())
+	s.Unlock()
+	if bar != "##--" {
+		t.Errorf("\n - Wrong ASCII bar, got: %q", bar)
+	}
+}
+
+func TestASCIIFallbackRegistry(t *testing.T) {
+	slash, _ := lookupAnimation(Slash)
+	unicode, _ := NewAnimation([]string{"◐", "◓"}, time.Second)
+	RegisterAnimation("slash", unicode)
+	defer RegisterAnimation("slash", slash)
+
+	dots, _ := lookupAnimation(Dots)
+	if got := dots.asciiFallback().frames; !reflect.DeepEqual(got, slash.frames) {
+		t.Errorf("\n - Registering slash shouldn't change the fallback, got: %q", got)
+	}
+
+	// The fallback is used while the animations are registered.
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 50; i++ {
+			RegisterAnimation("ascii-fallback-test", unicode)
+		}
+	}()
+	for i := 0; i < 50; i++ {
+		s, _ := NewSpinnerWithAnimation(unicode, WithWriter(io.Discard), WithCharsetMode(CharsetASCII))
+		s.Start("test")
+		s.Stop()
+	}
+	<-done
+}
@@ -0,0 +1,53 @@
+package gospinner
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"time"
+)
+
+// cliSpinner is an animation on the cli-spinners format
+type cliSpinner struct {
+	// Interval is the interval between frames in milliseconds
+	Interval int      `json:"interval"`
+	Frames   []string `json:"frames"`
+}
+
+// LoadAnimations parses the animations of r on the cli-spinners JSON format
+// (https://github.com/sindresorhus/cli-spinners), an object with the names of
+// the animations as keys and objects with the interval in milliseconds and the
+// frames as values.
+func LoadAnimations(r io.Reader) (map[string]Animation, error) {
+	spinners := map[string]cliSpinner{}
+	if err := json.NewDecoder(r).Decode(&spinners); err != nil {
+		return nil, fmt.Errorf("could not parse animations: %s", err)
+	}
+
+	animations := make(map[string]Animation, len(spinners))
+	for name, sp := range spinners {
+		an, err := sp.animation()
+		if err != nil {
+			return nil, fmt.Errorf("wrong animation %q: %s", name, err)
+		}
+		animations[name] = an
+	}
+	return animations, nil
+}
+
+// LoadAnimationsFS is the same as LoadAnimations but reading the animations
+// from the file of a file system.
+func LoadAnimationsFS(fsys fs.FS, name string) (map[string]Animation, error) {
+	f, err := fsys.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return LoadAnimations(f)
+}
+
+// animation returns the cli spinner as an animation
+func (c cliSpinner) animation() (Animation, error) {
+	return NewAnimation(c.Frames, time.Duration(c.Interval)*time.Millisecond)
+}
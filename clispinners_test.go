@@ -0,0 +1,79 @@
+package gospinner
+
+import (
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+func TestLoadAnimations(t *testing.T) {
+	tests := []struct {
+		json string
+
+		want    map[string]Animation
+		wantErr bool
+	}{
+		{
+			`{"dots": {"interval": 80, "frames": ["⠋", "⠙"]}, "line": {"interval": 130, "frames": ["-", "\\"]}}`,
+			map[string]Animation{
+				"dots": {interval: 80 * time.Millisecond, frames: []string{"⠋", "⠙"}, width: 1},
+				"line": {interval: 130 * time.Millisecond, frames: []string{"-", "\\"}, width: 1},
+			},
+			false,
+		},
+		{`{}`, map[string]Animation{}, false},
+		{`{"dots": {"interval": 80, "frames": []}}`, nil, true},
+		{`{"dots": {"frames": ["⠋"]}}`, nil, true},
+		{`{"dots": [`, nil, true},
+	}
+
+	for _, test := range tests {
+		got, err := LoadAnimations(strings.NewReader(test.json))
+		if test.wantErr != (err != nil) {
+			t.Errorf("%+v\n - Wrong error, got: %v", test, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%+v\n - Wrong animations, got: %v, want: %v", test, got, test.want)
+		}
+	}
+}
+
+func TestLoadAnimationsFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"spinners.json": &fstest.MapFile{Data: []byte(`{"dots": {"interval": 80, "frames": ["⠋", "⠙"]}}`)},
+	}
+
+	got, err := LoadAnimationsFS(fsys, "spinners.json")
+	if err != nil {
+		t.Fatalf("\n - Load shouldn't fail, it did: %s", err)
+	}
+	want := map[string]Animation{"dots": {interval: 80 * time.Millisecond, frames: []string{"⠋", "⠙"}, width: 1}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("- Wrong animations, got: %v, want: %v", got, want)
+	}
+
+	if _, err := LoadAnimationsFS(fsys, "missing.json"); err == nil {
+		t.Errorf("\n - Load of a missing file should fail, it didn't")
+	}
+}
+
+func TestBuiltinAnimationsFile(t *testing.T) {
+	got, err := LoadAnimationsFS(os.DirFS("."), "sets.json")
+	if err != nil {
+		t.Fatalf("\n - Load shouldn't fail, it did: %s", err)
+	}
+
+	// sets.go should be generated from sets.json.
+	if len(got) != len(builtinNames) {
+		t.Errorf("- Wrong number of animations, got: %d, want: %d", len(got), len(builtinNames))
+	}
+	for kind, name := range builtinNames {
+		if !reflect.DeepEqual(got[name], animations[kind]) {
+			t.Errorf("- Animation %q should be the same, got: %v, want: %v", name, got[name], animations[kind])
+		}
+	}
+}
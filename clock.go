@@ -0,0 +1,153 @@
+package gospinner
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock is the source of time of the spinners, it can be replaced to control
+// the animation time, for example on tests.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+	// NewTicker returns a ticker that ticks with the interval of d.
+	NewTicker(d time.Duration) Ticker
+}
+
+// Ticker delivers ticks at intervals, like time.Ticker does.
+type Ticker interface {
+	// C returns the channel where the ticks are delivered.
+	C() <-chan time.Time
+	// Stop turns off the ticker, no more ticks will be delivered.
+	Stop()
+}
+
+// realClock is the clock based on the time package
+type realClock struct{}
+
+func (realClock) Now() time.Time { return time.Now() }
+func (realClock) NewTicker(d time.Duration) Ticker {
+	return &realTicker{t: time.NewTicker(d)}
+}
+
+// realTicker is a ticker based on time.Ticker
+type realTicker struct {
+	t *time.Ticker
+}
+
+func (r *realTicker) C() <-chan time.Time { return r.t.C }
+func (r *realTicker) Stop()               { r.t.Stop() }
+
+// FakeClock is a clock that only moves when it's advanced manually, it lets
+// test the animations in a deterministic way without sleeping.
+type FakeClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	tickers []*fakeTicker
+}
+
+// NewFakeClock returns a new fake clock set at now.
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{now: now}
+}
+
+// Now returns the current time of the clock.
+func (f *FakeClock) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+// NewTicker returns a ticker that will tick when the clock is advanced.
+func (f *FakeClock) NewTicker(d time.Duration) Ticker {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	t := &fakeTicker{
+		c:        make(chan time.Time),
+		handledc: make(chan struct{}, 1),
+		stopc:    make(chan struct{}),
+		interval: d,
+		next:     f.now.Add(d),
+	}
+	f.tickers = append(f.tickers, t)
+	return t
+}
+
+// Advance moves the clock forward and delivers all the ticks that happened
+// in the meantime, it will block until every tick is received by the running
+// tickers. The ticks of the spinners are also waited until they are handled,
+// so the frames are already rendered when it returns.
+func (f *FakeClock) Advance(d time.Duration) {
+	f.mu.Lock()
+	f.now = f.now.Add(d)
+	now := f.now
+	tickers := make([]*fakeTicker, len(f.tickers))
+	copy(tickers, f.tickers)
+	f.mu.Unlock()
+
+	for _, t := range tickers {
+		t.tick(now)
+	}
+}
+
+// fakeTicker is the ticker of the fake clock
+type fakeTicker struct {
+	c        chan time.Time
+	handledc chan struct{}
+	stopc    chan struct{}
+	stopOnce sync.Once
+	interval time.Duration
+
+	mu   sync.Mutex
+	next time.Time
+	// wait makes the ticker wait until each tick is handled
+	wait bool
+}
+
+func (t *fakeTicker) C() <-chan time.Time { return t.c }
+func (t *fakeTicker) Stop()               { t.stopOnce.Do(func() { close(t.stopc) }) }
+
+// waitHandled makes the ticker wait after each tick until it's handled
+func (t *fakeTicker) waitHandled() {
+	t.mu.Lock()
+	t.wait = true
+	t.mu.Unlock()
+}
+
+// handled is called by the animation loop when it has finished handling a
+// tick
+func (t *fakeTicker) handled() {
+	select {
+	case t.handledc <- struct{}{}:
+	default:
+	}
+}
+
+// tick delivers all the ticks until now and waits until each one is handled,
+// unless the ticker is stopped
+func (t *fakeTicker) tick(now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for t.interval > 0 && !t.next.After(now) {
+		select {
+		case t.c <- t.next:
+		case <-t.stopc:
+			return
+		}
+		if t.wait {
+			select {
+			case <-t.handledc:
+			case <-t.stopc:
+				return
+			}
+		}
+		t.next = t.next.Add(t.interval)
+	}
+}
+
+// tickHandler is implemented by the tickers that can wait until the
+// animation loop has finished handling each tick, like the fake ones
+type tickHandler interface {
+	waitHandled()
+	handled()
+}
@@ -0,0 +1,91 @@
+package gospinner
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestFakeClockAdvance(t *testing.T) {
+	tests := []struct {
+		interval time.Duration
+		advances []time.Duration
+
+		wantTicks int
+	}{
+		{10 * time.Millisecond, []time.Duration{5 * time.Millisecond}, 0},
+		{10 * time.Millisecond, []time.Duration{10 * time.Millisecond}, 1},
+		{10 * time.Millisecond, []time.Duration{35 * time.Millisecond}, 3},
+		{10 * time.Millisecond, []time.Duration{5 * time.Millisecond, 5 * time.Millisecond, 5 * time.Millisecond}, 1},
+		{1 * time.Second, []time.Duration{1 * time.Minute}, 60},
+	}
+
+	for _, test := range tests {
+		start := time.Now()
+		clock := NewFakeClock(start)
+		ticker := clock.NewTicker(test.interval)
+
+		got := 0
+		done := make(chan struct{})
+		go func() {
+			for range ticker.C() {
+				got++
+				if got == test.wantTicks {
+					break
+				}
+			}
+			close(done)
+		}()
+
+		total := time.Duration(0)
+		for _, d := range test.advances {
+			total += d
+			clock.Advance(d)
+		}
+		ticker.Stop()
+		if test.wantTicks > 0 {
+			<-done
+		}
+
+		if got != test.wantTicks {
+			t.Errorf("%+v\n - Wrong number of ticks, got: %d, want: %d", test, got, test.wantTicks)
+		}
+		if !clock.Now().Equal(start.Add(total)) {
+			t.Errorf("%+v\n - Wrong clock time, got: %v, want: %v", test, clock.Now(), start.Add(total))
+		}
+	}
+}
+
+func TestFakeClockAdvanceStoppedTicker(t *testing.T) {
+	clock := NewFakeClock(time.Now())
+	ticker := clock.NewTicker(1 * time.Millisecond)
+	ticker.Stop()
+
+	// Nobody is receiving, it shouldn't block.
+	clock.Advance(1 * time.Second)
+}
+
+func TestFakeClockAdvanceRendered(t *testing.T) {
+	var buf bytes.Buffer
+	s, _ := NewSpinnerNoColor(Ball)
+	s.Writer = &buf
+	s.separator = "|"
+	clock := NewFakeClock(time.Now())
+	s.SetClock(clock)
+
+	s.StartWithSpeed("test", 1*time.Second)
+	defer s.Stop()
+	want := ""
+	for _, frame := range []string{"◐", "◓", "◑"} {
+		clock.Advance(1 * time.Second)
+		want += "|" + frame + " test"
+
+		// The frame is rendered when Advance returns, without stopping.
+		s.Lock()
+		got := buf.String()
+		s.Unlock()
+		if got != want {
+			t.Errorf("- Wrong result, got: %q, want: %q", got, want)
+		}
+	}
+}
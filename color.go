@@ -1,6 +1,12 @@
 package gospinner
 
 import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
 	"github.com/fatih/color"
 )
 
@@ -34,14 +40,251 @@ const (
 	FgHiWhite
 )
 
-// Handy funciton to create new color function
-func newColor(cAttr ColorAttr) *Color {
+// The 256 and true colors are encoded in the ColorAttr with a flag on the
+// high bits, the basic ones are the fatih color attributes.
+const (
+	color256Flag ColorAttr = 1 << 24
+	colorRGBFlag ColorAttr = 1 << 25
+	colorValue   ColorAttr = 1<<24 - 1
+)
+
+// Color256 returns a color of the 256 colors palette.
+func Color256(n uint8) ColorAttr {
+	return color256Flag | ColorAttr(n)
+}
+
+// RGB returns a 24 bit true color.
+func RGB(r, g, b uint8) ColorAttr {
+	return colorRGBFlag | ColorAttr(r)<<16 | ColorAttr(g)<<8 | ColorAttr(b)
+}
+
+// Hex returns the true color of an hexadecimal code like "#ff8800" or "#f80",
+// it panics if the code is not valid, use ParseColor to handle the error.
+func Hex(code string) ColorAttr {
+	c, err := parseHex(code)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// parseHex parses an hexadecimal color code with or without "#"
+func parseHex(code string) (ColorAttr, error) {
+	h := strings.TrimPrefix(code, "#")
+	if len(h) == 3 {
+		h = string([]byte{h[0], h[0], h[1], h[1], h[2], h[2]})
+	}
+	if len(h) != 6 {
+		return 0, fmt.Errorf("wrong hex color %q", code)
+	}
+	v, err := strconv.ParseUint(h, 16, 32)
+	if err != nil {
+		return 0, fmt.Errorf("wrong hex color %q", code)
+	}
+	return colorRGBFlag | ColorAttr(v), nil
+}
+
+// rgb returns the red, green and blue components of the color
+func (c ColorAttr) rgb() (r, g, b uint8) {
+	switch {
+	case c&colorRGBFlag != 0:
+		return uint8(c >> 16), uint8(c >> 8), uint8(c)
+	case c&color256Flag != 0:
+		n := uint8(c)
+		switch {
+		case n < 16:
+			return basicPalette[n].rgb()
+		case n < 232:
+			n -= 16
+			return cubeLevels[n/36], cubeLevels[n/6%6], cubeLevels[n%6]
+		default:
+			v := 8 + (n-232)*10
+			return v, v, v
+		}
+	default:
+		for i, bc := range basicColors {
+			if bc == c {
+				return basicPalette[i].rgb()
+			}
+		}
+		return 0, 0, 0
+	}
+}
+
+// basicColors are the 16 basic colors in the order of the 256 colors palette
+var basicColors = []ColorAttr{
+	FgBlack, FgRed, FgGreen, FgYellow, FgBlue, FgMagenta, FgCyan, FgWhite,
+	FgHiBlack, FgHiRed, FgHiGreen, FgHiYellow, FgHiBlue, FgHiMagenta, FgHiCyan, FgHiWhite,
+}
+
+// basicPalette are the usual (xterm) values of the basic colors
+var basicPalette = []ColorAttr{
+	RGB(0, 0, 0), RGB(205, 0, 0), RGB(0, 205, 0), RGB(205, 205, 0),
+	RGB(0, 0, 238), RGB(205, 0, 205), RGB(0, 205, 205), RGB(229, 229, 229),
+	RGB(127, 127, 127), RGB(255, 0, 0), RGB(0, 255, 0), RGB(255, 255, 0),
+	RGB(92, 92, 255), RGB(255, 0, 255), RGB(0, 255, 255), RGB(255, 255, 255),
+}
+
+// cubeLevels are the values of each component on the 6x6x6 color cube of
+// the 256 colors palette
+var cubeLevels = []uint8{0, 95, 135, 175, 215, 255}
+
+// colorLevel is the amount of colors that a terminal can display
+type colorLevel int
+
+const (
+	noColorLevel colorLevel = iota
+	basicColorLevel
+	color256Level
+	trueColorLevel
+)
+
+// envColorLevel returns the color level of the terminal based on the
+// environment: COLORTERM announces the true colors, TERM the 256 colors and
+// FORCE_COLOR can force the level with 2 (256 colors) or 3 (true colors).
+func envColorLevel() colorLevel {
+	switch os.Getenv("FORCE_COLOR") {
+	case "2":
+		return color256Level
+	case "3":
+		return trueColorLevel
+	}
+	switch strings.ToLower(os.Getenv("COLORTERM")) {
+	case "truecolor", "24bit":
+		return trueColorLevel
+	}
+	term := strings.ToLower(os.Getenv("TERM"))
+	switch {
+	case strings.HasSuffix(term, "-direct"):
+		return trueColorLevel
+	case strings.Contains(term, "256color"):
+		return color256Level
+	}
+	return basicColorLevel
+}
+
+// downgrade returns the nearest color to c that can be displayed with the
+// color level
+func (c ColorAttr) downgrade(level colorLevel) ColorAttr {
+	switch {
+	case c&colorRGBFlag != 0 && level < trueColorLevel:
+		if level == color256Level {
+			return Color256(to256(c.rgb()))
+		}
+		return nearestBasic(c.rgb())
+	case c&color256Flag != 0 && level < color256Level:
+		if n := uint8(c); n < 16 {
+			return basicColors[n]
+		}
+		return nearestBasic(c.rgb())
+	}
+	return c
+}
+
+// to256 returns the nearest color of the 256 colors palette, from the color
+// cube or the gray ramp
+func to256(r, g, b uint8) uint8 {
+	if r == g && g == b {
+		switch {
+		case r < 8:
+			return 16
+		case r > 246:
+			return 231
+		default:
+			// The gray ramp has 24 steps from 8 to 238.
+			step := (int(r) - 8 + 5) / 10
+			if step > 23 {
+				step = 23
+			}
+			return uint8(232 + step)
+		}
+	}
+	level := func(v uint8) uint8 {
+		if v < 48 {
+			return 0
+		}
+		if v < 115 {
+			return 1
+		}
+		return (v - 35) / 40
+	}
+	return 16 + 36*level(r) + 6*level(g) + level(b)
+}
+
+// nearestBasic returns the basic color with the smallest distance to the
+// components
+func nearestBasic(r, g, b uint8) ColorAttr {
+	best, bestDist := FgBlack, -1
+	for i, p := range basicPalette {
+		pr, pg, pb := p.rgb()
+		dr, dg, db := int(r)-int(pr), int(g)-int(pg), int(b)-int(pb)
+		if d := dr*dr + dg*dg + db*db; bestDist < 0 || d < bestDist {
+			best, bestDist = basicColors[i], d
+		}
+	}
+	return best
+}
+
+// attributes returns the fatih color attributes of the color
+func (c ColorAttr) attributes() []color.Attribute {
+	switch {
+	case c&colorRGBFlag != 0:
+		r, g, b := c.rgb()
+		return []color.Attribute{38, 2, color.Attribute(r), color.Attribute(g), color.Attribute(b)}
+	case c&color256Flag != 0:
+		return []color.Attribute{38, 5, color.Attribute(uint8(c))}
+	}
+	return []color.Attribute{color.Attribute(c)}
+}
+
+// Handy funciton to create new color function, the color is downgraded to the
+// color level
+func newColor(cAttr ColorAttr, level colorLevel) *Color {
 	c := &Color{
-		Color: color.New(color.Attribute(cAttr)),
+		Color: color.New(cAttr.downgrade(level).attributes()...),
 	}
+	c.EnableColor()
 	return c
 }
 
-func noColor() {
-	color.NoColor = true
+// ColorMode is the way the spinner decides if it uses colors
+type ColorMode int
+
+const (
+	// ColorAuto uses colors unless NO_COLOR is set or the writer is a file
+	// that is not a terminal, like a log file, or a terminal with TERM=dumb.
+	// FORCE_COLOR forces the colors unless it's 0 or false.
+	ColorAuto ColorMode = iota
+	// ColorAlways always uses colors.
+	ColorAlways
+	// ColorNever never uses colors.
+	ColorNever
+)
+
+// useColor returns true if the spinner will use colors on the writer with the
+// color mode
+func useColor(mode ColorMode, w io.Writer) bool {
+	switch mode {
+	case ColorAlways:
+		return true
+	case ColorNever:
+		return false
+	}
+	if force, ok := os.LookupEnv("FORCE_COLOR"); ok {
+		return force != "0" && force != "false"
+	}
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	terminal, ok := isTerminal(w)
+	return !ok || terminal && os.Getenv("TERM") != "dumb"
+}
+
+// SetColorMode sets if the spinner uses colors, by default ColorAuto, it will
+// be used from the next start. The decision is taken for the writer of each
+// spinner so it doesn't affect other spinners.
+func (s *Spinner) SetColorMode(mode ColorMode) {
+	s.Lock()
+	s.colorMode = mode
+	s.Unlock()
 }
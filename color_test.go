@@ -0,0 +1,201 @@
+package gospinner
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestEnvColorLevel(t *testing.T) {
+	tests := []struct {
+		force     string
+		colorTerm string
+		term      string
+
+		want colorLevel
+	}{
+		{"", "", "xterm", basicColorLevel},
+		{"", "", "xterm-256color", color256Level},
+		{"", "truecolor", "xterm-256color", trueColorLevel},
+		{"", "24bit", "xterm", trueColorLevel},
+		{"", "", "xterm-direct", trueColorLevel},
+		{"2", "", "xterm", color256Level},
+		{"3", "", "xterm", trueColorLevel},
+	}
+
+	for _, test := range tests {
+		t.Setenv("FORCE_COLOR", test.force)
+		t.Setenv("COLORTERM", test.colorTerm)
+		t.Setenv("TERM", test.term)
+		if got := envColorLevel(); got != test.want {
+			t.Errorf("%+v\n - Wrong color level, got: %d, want: %d", test, got, test.want)
+		}
+	}
+}
+
+func TestColorDowngrade(t *testing.T) {
+	tests := []struct {
+		color ColorAttr
+		level colorLevel
+
+		want ColorAttr
+	}{
+		{Hex("#ff8800"), trueColorLevel, RGB(255, 136, 0)},
+		{Hex("#ff8700"), color256Level, Color256(208)},
+		{Hex("#808080"), color256Level, Color256(244)},
+		{Hex("#000000"), color256Level, Color256(16)},
+		{Hex("#ffffff"), color256Level, Color256(231)},
+		{Hex("#eeeeee"), color256Level, Color256(255)},
+		{Hex("#f3f3f3"), color256Level, Color256(255)},
+		{Hex("#f5f5f5"), color256Level, Color256(255)},
+		{Hex("#f6f6f6"), color256Level, Color256(255)},
+		{Hex("#f7f7f7"), color256Level, Color256(231)},
+		{Hex("#ff8800"), basicColorLevel, FgYellow},
+		{Hex("#0000ee"), basicColorLevel, FgBlue},
+		{Color256(208), color256Level, Color256(208)},
+		{Color256(9), basicColorLevel, FgHiRed},
+		{Color256(46), basicColorLevel, FgHiGreen},
+		{Color256(232), basicColorLevel, FgBlack},
+		{FgCyan, basicColorLevel, FgCyan},
+	}
+
+	for _, test := range tests {
+		if got := test.color.downgrade(test.level); got != test.want {
+			t.Errorf("%+v\n - Wrong downgrade, got: %s, want: %s", test, got, test.want)
+		}
+	}
+}
+
+func TestExtendedColorSpinner(t *testing.T) {
+	tests := []struct {
+		colorTerm string
+		term      string
+
+		want string
+	}{
+		{"truecolor", "xterm", "\x1b[38;2;255;136;0m-\x1b[0m"},
+		{"", "xterm-256color", "\x1b[38;5;208m-\x1b[0m"},
+		{"", "xterm", "\x1b[33m-\x1b[0m"},
+	}
+
+	for _, test := range tests {
+		t.Setenv("NO_COLOR", "")
+		t.Setenv("COLORTERM", test.colorTerm)
+		t.Setenv("TERM", test.term)
+		s, err := NewSpinnerWithColor(Slash, Hex("#ff8800"))
+		if err != nil {
+			t.Fatalf("%+v\n - Creation shouldn't fail, it did: %s", test, err)
+		}
+		s.createFrames()
+		if s.symbols[0] != test.want {
+			t.Errorf("%+v\n - Wrong symbol, got: %q, want: %q", test, s.symbols[0], test.want)
+		}
+	}
+}
+
+func TestExtendedFinisherColors(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	t.Setenv("COLORTERM", "truecolor")
+	var b bytes.Buffer
+	an, _ := lookupAnimation(Slash)
+	s, _ := NewSpinnerWithAnimation(an, WithWriter(&b), WithSucceedColor(RGB(1, 2, 3)))
+	s.Start("test")
+	s.Succeed()
+
+	want := "\x1b[38;2;1;2;3m✔\x1b[0m test"
+	if !bytes.Contains(b.Bytes(), []byte(want)) {
+		t.Errorf("\n - Wrong finisher color, got: %q, want it to contain: %q", b.String(), want)
+	}
+}
+
+func TestUseColor(t *testing.T) {
+	f, err := os.CreateTemp(t.TempDir(), "log")
+	if err != nil {
+		t.Fatalf("\n - Temp file creation shouldn't fail, it did: %s", err)
+	}
+	defer f.Close()
+
+	tests := []struct {
+		mode    ColorMode
+		w       io.Writer
+		noColor string
+		force   *string
+
+		want bool
+	}{
+		{ColorAuto, &bytes.Buffer{}, "", nil, true},
+		{ColorAuto, f, "", nil, false},
+		{ColorAuto, &bytes.Buffer{}, "1", nil, false},
+		{ColorAuto, f, "", strPtr("1"), true},
+		{ColorAuto, &bytes.Buffer{}, "1", strPtr(""), true},
+		{ColorAuto, &bytes.Buffer{}, "", strPtr("0"), false},
+		{ColorAlways, f, "1", nil, true},
+		{ColorNever, &bytes.Buffer{}, "", strPtr("1"), false},
+	}
+
+	for _, test := range tests {
+		t.Setenv("NO_COLOR", test.noColor)
+		t.Setenv("FORCE_COLOR", "")
+		os.Unsetenv("FORCE_COLOR")
+		if test.force != nil {
+			t.Setenv("FORCE_COLOR", *test.force)
+		}
+		if got := useColor(test.mode, test.w); got != test.want {
+			t.Errorf("%+v\n - Wrong color usage, got: %t, want: %t", test, got, test.want)
+		}
+	}
+}
+
+func strPtr(s string) *string {
+	return &s
+}
+
+func TestPerSpinnerColor(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	f, err := os.CreateTemp(t.TempDir(), "log")
+	if err != nil {
+		t.Fatalf("\n - Temp file creation shouldn't fail, it did: %s", err)
+	}
+	defer f.Close()
+
+	var b bytes.Buffer
+	colored, _ := NewSpinnerWithColor(Slash, FgRed)
+	colored.Writer = &b
+	plain, _ := NewSpinnerWithColor(Slash, FgRed)
+	plain.Writer = f
+	plain.SetRenderMode(AnimatedMode)
+
+	colored.Start("colored")
+	plain.Start("plain")
+	plain.Succeed()
+	colored.Succeed()
+
+	if !strings.Contains(b.String(), "\x1b[92m") {
+		t.Errorf("\n - The spinner on a buffer should have color, got: %q", b.String())
+	}
+	got, _ := os.ReadFile(f.Name())
+	if strings.Contains(string(got), "\x1b[") {
+		t.Errorf("\n - The spinner on a file should not have color, got: %q", got)
+	}
+}
+
+func TestNoColorEnv(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+	var b bytes.Buffer
+	s, _ := NewSpinnerWithColor(Slash, RGB(255, 136, 0))
+	s.Writer = &b
+	s.Start("test")
+	s.Stop()
+	if s.symbols[0] != "-" {
+		t.Errorf("\n - NO_COLOR should disable the color, got: %q", s.symbols[0])
+	}
+
+	s.SetColorMode(ColorAlways)
+	s.Start("test")
+	s.Stop()
+	if s.symbols[0] == "-" {
+		t.Errorf("\n - ColorAlways should ignore NO_COLOR, got: %q", s.symbols[0])
+	}
+}
@@ -0,0 +1,109 @@
+package gospinner
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+	"sync"
+)
+
+// RunCommand starts the spinner with the message and runs the command, while
+// it's running the last line of its output will be shown dimmed after the
+// message, except on line mode. The spinner will be finished with Succeed or
+// Fail depending on the command result, on failure the whole output will be
+// written below the failed line. The output is also written to the Stdout and
+// Stderr of the command if they are set.
+func RunCommand(s *Spinner, message string, cmd *exec.Cmd) error {
+	tw := &tailWriter{spinner: s, message: message}
+	if cmd.Stdout == cmd.Stderr {
+		// Both streams share a writer so exec.Cmd doesn't write to it from
+		// two goroutines.
+		cmd.Stdout = teeWriter(cmd.Stdout, tw)
+		cmd.Stderr = cmd.Stdout
+	} else {
+		cmd.Stdout = teeWriter(cmd.Stdout, tw)
+		cmd.Stderr = teeWriter(cmd.Stderr, tw)
+	}
+
+	if err := s.Start(message); err != nil {
+		return err
+	}
+
+	err := cmd.Run()
+	if !s.isLineMode() {
+		s.SetMessage(message)
+	}
+	if err != nil {
+		s.Fail()
+		if out := tw.output(); len(out) > 0 {
+			s.Write(out)
+		}
+		return err
+	}
+
+	s.Succeed()
+	return nil
+}
+
+// teeWriter returns a writer that writes to w and tw, or only tw if w is nil
+func teeWriter(w io.Writer, tw *tailWriter) io.Writer {
+	if w == nil {
+		return tw
+	}
+	return io.MultiWriter(w, tw)
+}
+
+// tailWriter captures the output of a command and sets the last line of it
+// as the detail of the spinner message
+type tailWriter struct {
+	spinner *Spinner
+	message string
+
+	out     bytes.Buffer
+	partial []byte
+	sync.Mutex
+}
+
+func (t *tailWriter) Write(p []byte) (int, error) {
+	t.Lock()
+	defer t.Unlock()
+	t.out.Write(p)
+
+	// The lines on line mode would be written again with the message.
+	if t.spinner.isLineMode() {
+		return len(p), nil
+	}
+
+	t.partial = append(t.partial, p...)
+	i := bytes.LastIndexByte(t.partial, '\n')
+	if i < 0 {
+		return len(p), nil
+	}
+
+	// Get the last non empty line of the complete ones.
+	lines := strings.Split(string(t.partial[:i]), "\n")
+	t.partial = t.partial[i+1:]
+	for j := len(lines) - 1; j >= 0; j-- {
+		if l := strings.TrimSpace(lines[j]); l != "" {
+			t.spinner.SetMessage(fmt.Sprintf("%s [dim]%s[/]", t.message, EscapeMarkup(l)))
+			break
+		}
+	}
+	return len(p), nil
+}
+
+// output returns all the captured output
+func (t *tailWriter) output() []byte {
+	t.Lock()
+	defer t.Unlock()
+	return t.out.Bytes()
+}
+
+// isLineMode returns true if the running spinner is on line mode
+func (s *Spinner) isLineMode() bool {
+	s.Lock()
+	defer s.Unlock()
+	return s.lineMode
+}
@@ -0,0 +1,111 @@
+package gospinner
+
+import (
+	"bytes"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+func TestRunCommand(t *testing.T) {
+	tests := []struct {
+		script string
+
+		wantErr bool
+		want    string
+	}{
+		{"echo out; echo err >&2", false, "✔ build\n"},
+		{"echo out; echo err >&2; exit 3", true, "✖ build\nout\nerr\n"},
+		{"printf out; exit 1", true, "✖ build\nout\n"},
+	}
+
+	for _, test := range tests {
+		var buf bytes.Buffer
+		s, _ := NewSpinnerNoColor(Slash)
+		s.Writer = &buf
+
+		err := RunCommand(s, "build", exec.Command("sh", "-c", test.script))
+		if test.wantErr != (err != nil) {
+			t.Errorf("%+v\n - Wrong error, got: %v", test, err)
+		}
+		if got := buf.String(); !strings.HasSuffix(got, test.want) {
+			t.Errorf("%+v\n - Wrong result, got: %q, want: %q", test, got, test.want)
+		}
+	}
+}
+
+func TestRunCommandLineMode(t *testing.T) {
+	var buf bytes.Buffer
+	s, _ := NewSpinnerNoColor(Slash)
+	s.Writer = &buf
+	s.SetRenderMode(LineMode)
+
+	err := RunCommand(s, "build", exec.Command("sh", "-c", "echo out; echo err >&2; exit 1"))
+	if err == nil {
+		t.Errorf("\n - The command should fail, it didn't")
+	}
+	if want := "build\n✖ build\nout\nerr\n"; buf.String() != want {
+		t.Errorf("\n - Wrong result, got: %q, want: %q", buf.String(), want)
+	}
+}
+
+func TestRunCommandKeepsOutputs(t *testing.T) {
+	var buf, stdout, stderr bytes.Buffer
+	s, _ := NewSpinnerNoColor(Slash)
+	s.Writer = &buf
+
+	cmd := exec.Command("sh", "-c", "echo out; echo err >&2")
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := RunCommand(s, "build", cmd); err != nil {
+		t.Fatalf("\n - The command shouldn't fail, it did: %s", err)
+	}
+	if stdout.String() != "out\n" || stderr.String() != "err\n" {
+		t.Errorf("\n - The output should be written to the command writers, got: %q and %q", stdout.String(), stderr.String())
+	}
+}
+
+func TestRunCommandSharedOutput(t *testing.T) {
+	var buf, out bytes.Buffer
+	s, _ := NewSpinnerNoColor(Slash)
+	s.Writer = &buf
+
+	cmd := exec.Command("sh", "-c", "for i in 1 2 3 4 5 6 7 8 9 10; do echo out $i; echo err $i >&2; done")
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+	if err := RunCommand(s, "build", cmd); err != nil {
+		t.Fatalf("\n - The command shouldn't fail, it did: %s", err)
+	}
+	if got := strings.Count(out.String(), "\n"); got != 20 {
+		t.Errorf("\n - The output should be written to the shared writer, got: %d lines", got)
+	}
+}
+
+func TestTailWriter(t *testing.T) {
+	tests := []struct {
+		writes []string
+
+		want string
+	}{
+		{[]string{"out\n"}, "build out"},
+		{[]string{"out 1\nout 2\n"}, "build out 2"},
+		{[]string{"out 1\nout", " 2\n"}, "build out 2"},
+		{[]string{"out 1\n", "out 2"}, "build out 1"},
+		{[]string{"out 1\n\n  \n"}, "build out 1"},
+		{[]string{"out 1\r\n"}, "build out 1"},
+		{[]string{"[red]ERROR[/] in [bold] file\n"}, "build [red]ERROR[/] in [bold] file"},
+	}
+
+	for _, test := range tests {
+		s, _ := NewSpinnerNoColor(Slash)
+		s.message = "build"
+		tw := &tailWriter{spinner: s, message: "build"}
+		for _, w := range test.writes {
+			tw.Write([]byte(w))
+		}
+
+		if s.message != test.want {
+			t.Errorf("%+v\n - Wrong message, got: %q, want: %q", test, s.message, test.want)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package gospinner
+
+import (
+	"math"
+)
+
+// minCycleFrames is the minimum number of frames of a color cycle, short
+// animations are repeated so the color changes smoothly
+const minCycleFrames = 12
+
+// ColorCycle returns the color of the frame i of n frames, it changes the
+// color of the animation over time.
+type ColorCycle func(i, n int) ColorAttr
+
+// Gradient returns a color cycle that goes from one color to the next one
+// and from the last one back to the first one.
+func Gradient(colors ...ColorAttr) ColorCycle {
+	if len(colors) == 0 {
+		return nil
+	}
+	return func(i, n int) ColorAttr {
+		pos := float64(i) / float64(n) * float64(len(colors))
+		k := int(pos)
+		return mix(colors[k%len(colors)], colors[(k+1)%len(colors)], pos-float64(k))
+	}
+}
+
+// Rainbow returns a color cycle that goes through all the hues.
+func Rainbow() ColorCycle {
+	return func(i, n int) ColorAttr {
+		return hue(float64(i) / float64(n) * 360)
+	}
+}
+
+// Pulse returns a color cycle that dims the color and brightens it again.
+func Pulse(color ColorAttr) ColorCycle {
+	return func(i, n int) ColorAttr {
+		dim := (1 - math.Cos(2*math.Pi*float64(i)/float64(n))) / 2
+		return mix(color, RGB(0, 0, 0), 0.7*dim)
+	}
+}
+
+// mix returns the color at t (from 0 to 1) between the colors a and b
+func mix(a, b ColorAttr, t float64) ColorAttr {
+	ar, ag, ab := a.rgb()
+	br, bg, bb := b.rgb()
+	c := func(x, y uint8) uint8 {
+		return uint8(math.Round(float64(x) + (float64(y)-float64(x))*t))
+	}
+	return RGB(c(ar, br), c(ag, bg), c(ab, bb))
+}
+
+// hue returns the color of the hue h in degrees with full saturation and value
+func hue(h float64) ColorAttr {
+	x := uint8(math.Round(255 * (1 - math.Abs(math.Mod(h/60, 2)-1))))
+	switch int(h/60) % 6 {
+	case 0:
+		return RGB(255, x, 0)
+	case 1:
+		return RGB(x, 255, 0)
+	case 2:
+		return RGB(0, 255, x)
+	case 3:
+		return RGB(0, x, 255)
+	case 4:
+		return RGB(x, 0, 255)
+	default:
+		return RGB(255, 0, x)
+	}
+}
+
+// SetColorCycle sets a color cycle that changes the color of the animation on
+// each frame, nil goes back to the color of the spinner. The cycle is ignored
+// when the colors are disabled.
+func (s *Spinner) SetColorCycle(cycle ColorCycle) {
+	s.Lock()
+	s.cycle = cycle
+	s.createFrames()
+	s.Unlock()
+}
+
+// cycleSymbols returns the animation characters colored by the color cycle,
+// the lock needs to be held
+func (s *Spinner) cycleSymbols() []string {
+	frames := s.animationFrames()
+	n := len(frames) * ((minCycleFrames + len(frames) - 1) / len(frames))
+	symbols := make([]string, n)
+	for i := range symbols {
+		c := newColor(s.cycle(i, n), s.colorLevel)
+		symbols[i] = c.Sprint(s.markup(frames[i%len(frames)]))
+	}
+	return symbols
+}
@@ -0,0 +1,71 @@
+package gospinner
+
+import (
+	"testing"
+)
+
+func TestColorCycles(t *testing.T) {
+	tests := []struct {
+		name  string
+		cycle ColorCycle
+		n     int
+
+		want []ColorAttr
+	}{
+		{"gradient", Gradient(RGB(0, 0, 0), RGB(200, 100, 0)), 4, []ColorAttr{RGB(0, 0, 0), RGB(100, 50, 0), RGB(200, 100, 0), RGB(100, 50, 0)}},
+		{"one color gradient", Gradient(FgRed), 2, []ColorAttr{RGB(205, 0, 0), RGB(205, 0, 0)}},
+		{"rainbow", Rainbow(), 6, []ColorAttr{RGB(255, 0, 0), RGB(255, 255, 0), RGB(0, 255, 0), RGB(0, 255, 255), RGB(0, 0, 255), RGB(255, 0, 255)}},
+		{"pulse", Pulse(RGB(100, 200, 0)), 4, []ColorAttr{RGB(100, 200, 0), RGB(65, 130, 0), RGB(30, 60, 0), RGB(65, 130, 0)}},
+	}
+
+	for _, test := range tests {
+		for i, want := range test.want {
+			if got := test.cycle(i, test.n); got != want {
+				t.Errorf("%s\n - Wrong color of frame %d, got: %s, want: %s", test.name, i, got, want)
+			}
+		}
+	}
+
+	if Gradient() != nil {
+		t.Errorf("\n - Gradient without colors should be nil")
+	}
+}
+
+func TestColorCycleFrames(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	t.Setenv("COLORTERM", "truecolor")
+	an, _ := lookupAnimation(Slash)
+	s, _ := NewSpinnerWithAnimation(an, WithColorCycle(Rainbow()))
+	s.createFrames()
+
+	if len(s.frames) != 12 || len(s.symbols) != 12 {
+		t.Fatalf("\n - Short animations should be repeated, got: %d frames", len(s.frames))
+	}
+	if want := "\x1b[38;2;255;0;0m-\x1b[0m"; s.symbols[0] != want {
+		t.Errorf("\n - Wrong first symbol, got: %q, want: %q", s.symbols[0], want)
+	}
+	if want := "\x1b[38;2;255;255;0m|\x1b[0m"; s.symbols[2] != want {
+		t.Errorf("\n - Wrong third symbol, got: %q, want: %q", s.symbols[2], want)
+	}
+
+	s.SetColorCycle(nil)
+	if len(s.frames) != 4 {
+		t.Errorf("\n - Removing the cycle should go back to the animation frames, got: %d frames", len(s.frames))
+	}
+}
+
+func TestColorCycleWithoutColor(t *testing.T) {
+	an, _ := lookupAnimation(Slash)
+	s, _ := NewSpinnerWithAnimation(an, WithColorCycle(Rainbow()), WithoutColor())
+	s.createFrames()
+
+	want := []string{"-", "\\", "|", "/"}
+	if len(s.symbols) != len(want) {
+		t.Fatalf("\n - Without color the cycle should be ignored, got: %q", s.symbols)
+	}
+	for i := range want {
+		if s.symbols[i] != want[i] {
+			t.Errorf("\n - Wrong symbol, got: %q, want: %q", s.symbols[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,21 @@
+package gospinner
+
+import (
+	"fmt"
+	"time"
+)
+
+// formatElapsed formats a duration in a human friendly way, precise will show
+// the tenths of second for durations under a minute.
+func formatElapsed(d time.Duration, precise bool) string {
+	switch {
+	case d < time.Minute && precise:
+		return fmt.Sprintf("%.1fs", d.Seconds())
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm%ds", int(d.Minutes()), int(d.Seconds())%60)
+	default:
+		return fmt.Sprintf("%dh%dm", int(d.Hours()), int(d.Minutes())%60)
+	}
+}
@@ -0,0 +1,113 @@
+package gospinner
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFormatElapsed(t *testing.T) {
+	tests := []struct {
+		d       time.Duration
+		precise bool
+
+		want string
+	}{
+		{0, false, "0s"},
+		{0, true, "0.0s"},
+		{12*time.Second + 600*time.Millisecond, false, "12s"},
+		{14*time.Second + 300*time.Millisecond, true, "14.3s"},
+		{62 * time.Second, false, "1m2s"},
+		{62*time.Second + 500*time.Millisecond, true, "1m2s"},
+		{2*time.Hour + 5*time.Minute + 3*time.Second, true, "2h5m"},
+	}
+
+	for _, test := range tests {
+		got := formatElapsed(test.d, test.precise)
+		if got != test.want {
+			t.Errorf("%+v\n - Wrong format, got: %v, want: %v", test, got, test.want)
+		}
+	}
+}
+
+func TestRenderElapsed(t *testing.T) {
+	want := "|◐ test (1s)|◓ test (2s)|◑ test (3s)"
+
+	var buf bytes.Buffer
+	s, _ := NewSpinnerNoColor(Ball)
+	s.Writer = &buf
+	s.separator = "|"
+	s.SetShowElapsed(true)
+	clock := NewFakeClock(time.Now())
+	s.SetClock(clock)
+
+	s.StartWithSpeed("test", 1*time.Second)
+	for i := 0; i < 3; i++ {
+		clock.Advance(1 * time.Second)
+	}
+	s.Stop()
+
+	if got := buf.String(); got != want {
+		t.Errorf("- Wrong result, got: %v, want: %v", got, want)
+	}
+}
+
+func TestRenderElapsedAfterStop(t *testing.T) {
+	var buf bytes.Buffer
+	s, _ := NewSpinnerNoColor(Ball)
+	s.Writer = &buf
+	s.separator = "|"
+	s.SetShowElapsed(true)
+	clock := NewFakeClock(time.Now())
+	s.SetClock(clock)
+
+	s.StartWithSpeed("test", 1*time.Hour)
+	s.Stop()
+
+	// A tick received by the loop before the stop is rendered after it.
+	s.Lock()
+	s.render(clock.Now().Add(3 * time.Second))
+	s.Unlock()
+	if want := "|◐ test (3s)"; buf.String() != want {
+		t.Errorf("- Wrong result, got: %q, want: %q", buf.String(), want)
+	}
+}
+
+func TestFinishElapsed(t *testing.T) {
+	tests := []struct {
+		finisher    Finisher
+		showElapsed bool
+
+		want string
+	}{
+		{(*Spinner).Succeed, true, "✔ test (14.3s)\n"},
+		{(*Spinner).Fail, true, "✖ test (14.3s)\n"},
+		{(*Spinner).Warn, true, "⚠ test (14.3s)\n"},
+		{(*Spinner).Succeed, false, "✔ test\n"},
+	}
+
+	for _, test := range tests {
+		var buf bytes.Buffer
+		s, _ := NewSpinnerNoColor(Ball)
+		s.Writer = &buf
+		s.SetShowElapsed(test.showElapsed)
+		clock := NewFakeClock(time.Now())
+		s.SetClock(clock)
+
+		s.StartWithSpeed("test", 1*time.Hour)
+		clock.Advance(14*time.Second + 300*time.Millisecond)
+		if got := s.Elapsed(); got != 14*time.Second+300*time.Millisecond {
+			t.Errorf("%+v\n - Wrong elapsed time, got: %v", test, got)
+		}
+		test.finisher(s)
+		clock.Advance(1 * time.Second)
+
+		if got := s.Elapsed(); got != 14*time.Second+300*time.Millisecond {
+			t.Errorf("%+v\n - Wrong elapsed time after finishing, got: %v", test, got)
+		}
+		if !strings.HasSuffix(buf.String(), test.want) {
+			t.Errorf("%+v\n - Wrong frame rendered, got: %v, want: %v", test, buf.String(), test.want)
+		}
+	}
+}
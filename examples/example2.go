@@ -38,14 +38,12 @@ func main() {
 				break
 			}
 
-			msg := fmt.Sprintf("%s (%d%%)", p, total)
-			s.SetMessage(msg)
+			s.SetProgress(int64(total), 100)
 
 			ms := r.Intn(1000)
 			time.Sleep(time.Duration(ms) * time.Millisecond)
 
 		}
-		s.SetMessage(fmt.Sprintf(p))
 		s.Succeed()
 	}
 
@@ -0,0 +1,120 @@
+// Command gensets generates the built-in animations of gospinner from a file
+// on the cli-spinners JSON format. The order of the animations on the file is
+// the order of the AnimationKind constants, new animations should be appended
+// so the values of the existing kinds don't change.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/format"
+	"os"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// spinner is an animation on the cli-spinners format
+type spinner struct {
+	name     string
+	Interval int      `json:"interval"`
+	Frames   []string `json:"frames"`
+}
+
+func main() {
+	in := flag.String("in", "sets.json", "the cli-spinners JSON file")
+	out := flag.String("out", "sets.go", "the generated Go file")
+	flag.Parse()
+
+	if err := run(*in, *out); err != nil {
+		fmt.Fprintf(os.Stderr, "gensets: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(in, out string) error {
+	data, err := os.ReadFile(in)
+	if err != nil {
+		return err
+	}
+	spinners, err := parse(data)
+	if err != nil {
+		return err
+	}
+	src, err := generate(in, spinners)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(out, src, 0644)
+}
+
+// parse parses the spinners keeping the order of the file
+func parse(data []byte) ([]spinner, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if t, err := dec.Token(); err != nil || t != json.Delim('{') {
+		return nil, fmt.Errorf("expected an object of animations")
+	}
+
+	spinners := []spinner{}
+	for dec.More() {
+		t, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		sp := spinner{name: t.(string)}
+		if err := dec.Decode(&sp); err != nil {
+			return nil, fmt.Errorf("wrong animation %q: %s", sp.name, err)
+		}
+		if len(sp.Frames) == 0 || sp.Interval <= 0 {
+			return nil, fmt.Errorf("wrong animation %q: it needs frames and an interval", sp.name)
+		}
+		spinners = append(spinners, sp)
+	}
+	return spinners, nil
+}
+
+// kindName returns the name of the AnimationKind constant of an animation
+func kindName(name string) string {
+	r := []rune(name)
+	r[0] = unicode.ToUpper(r[0])
+	return string(r)
+}
+
+// generate returns the source of the built-in animations
+func generate(in string, spinners []spinner) ([]byte, error) {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by gensets from %s; DO NOT EDIT.\n\n", in)
+	b.WriteString("package gospinner\n\nimport \"time\"\n\n")
+
+	b.WriteString("const (\n")
+	for i, sp := range spinners {
+		if i == 0 {
+			fmt.Fprintf(&b, "\t%s AnimationKind = iota\n", kindName(sp.name))
+			continue
+		}
+		fmt.Fprintf(&b, "\t%s\n", kindName(sp.name))
+	}
+	b.WriteString(")\n\n")
+
+	b.WriteString("var animations = map[AnimationKind]Animation{\n")
+	for _, sp := range spinners {
+		frames := make([]string, len(sp.Frames))
+		for i, f := range sp.Frames {
+			frames[i] = strconv.Quote(f)
+		}
+		fmt.Fprintf(&b, "\t%s: Animation{interval: %d * time.Millisecond, frames: []string{%s}},\n",
+			kindName(sp.name), sp.Interval, strings.Join(frames, ", "))
+	}
+	b.WriteString("}\n\n")
+
+	b.WriteString("// builtinNames are the names of the built-in animations\n")
+	b.WriteString("var builtinNames = map[AnimationKind]string{\n")
+	for _, sp := range spinners {
+		fmt.Fprintf(&b, "\t%s: %q,\n", kindName(sp.name), sp.name)
+	}
+	b.WriteString("}\n")
+
+	return format.Source(b.Bytes())
+}
@@ -0,0 +1,118 @@
+package gospinner
+
+import (
+	"bytes"
+	"sync"
+	"unicode/utf8"
+)
+
+// maxLineLength is the max length of a line that will be buffered, longer lines
+// are written in chunks.
+const maxLineLength = 4096
+
+// LineWriter is an io.Writer that writes complete lines above the animation of
+// a spinner, useful to redirect a logger or the output of a command while the
+// spinner is running. Partial lines are buffered until the new line arrives,
+// the spinner is stopped or the writer flushed.
+type LineWriter struct {
+	spinner *Spinner
+	buf     []byte
+	sync.Mutex
+}
+
+// NewLineWriter creates a new line writer that writes above the spinner.
+func NewLineWriter(s *Spinner) *LineWriter {
+	lw := &LineWriter{spinner: s}
+	s.Lock()
+	s.lineWriters = append(s.lineWriters, lw)
+	s.Unlock()
+	return lw
+}
+
+// Write buffers p and writes every complete line above the spinner.
+func (lw *LineWriter) Write(p []byte) (int, error) {
+	lw.Lock()
+	defer lw.Unlock()
+	lw.buf = append(lw.buf, p...)
+	for {
+		i := bytes.IndexByte(lw.buf, '\n')
+		if i < 0 {
+			break
+		}
+		line := bytes.TrimSuffix(lw.buf[:i], []byte("\r"))
+		if err := lw.write(line); err != nil {
+			return len(p), err
+		}
+		lw.buf = lw.buf[i+1:]
+	}
+
+	// Don't let very long lines grow forever.
+	for len(lw.buf) >= maxLineLength {
+		n := cutLine(lw.buf)
+		if err := lw.write(lw.buf[:n]); err != nil {
+			return len(p), err
+		}
+		lw.buf = lw.buf[n:]
+	}
+	return len(p), nil
+}
+
+// cutLine returns where a line longer than maxLineLength is cut, at most at
+// maxLineLength but before the last rune if it would be split
+func cutLine(b []byte) int {
+	n := maxLineLength
+	for i := n - 1; i >= 0 && i >= n-utf8.UTFMax; i-- {
+		if utf8.RuneStart(b[i]) {
+			if !utf8.FullRune(b[i:n]) {
+				n = i
+			}
+			break
+		}
+	}
+	if n == 0 {
+		return maxLineLength
+	}
+	return n
+}
+
+// Flush writes the buffered partial line above the spinner.
+func (lw *LineWriter) Flush() error {
+	lw.Lock()
+	defer lw.Unlock()
+	return lw.flush()
+}
+
+// Close flushes the writer and detaches it from the spinner.
+func (lw *LineWriter) Close() error {
+	lw.Lock()
+	defer lw.Unlock()
+
+	s := lw.spinner
+	s.Lock()
+	for i, w := range s.lineWriters {
+		if w == lw {
+			s.lineWriters = append(s.lineWriters[:i], s.lineWriters[i+1:]...)
+			break
+		}
+	}
+	s.Unlock()
+	return lw.flush()
+}
+
+// flush writes the buffered partial line, the lock needs to be held
+func (lw *LineWriter) flush() error {
+	if len(lw.buf) == 0 {
+		return nil
+	}
+	line := lw.buf
+	lw.buf = nil
+	return lw.write(line)
+}
+
+// write writes a line above the spinner, the lock needs to be held
+func (lw *LineWriter) write(line []byte) error {
+	l := make([]byte, len(line), len(line)+1)
+	copy(l, line)
+	_, err := lw.spinner.Write(append(l, '\n'))
+	return err
+}
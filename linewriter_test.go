@@ -0,0 +1,100 @@
+package gospinner
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+func TestLineWriter(t *testing.T) {
+	tests := []struct {
+		writes []string
+
+		want string
+	}{
+		{[]string{"log\n"}, "\r- test\r      \rlog\n\r- test"},
+		{[]string{"lo", "g\n"}, "\r- test\r      \rlog\n\r- test"},
+		{[]string{"log\r\n"}, "\r- test\r      \rlog\n\r- test"},
+		{[]string{"log 1\nlog 2\n"}, "\r- test\r      \rlog 1\n\r- test\r      \rlog 2\n\r- test"},
+		{[]string{"log"}, "\r- test"},
+	}
+
+	for _, test := range tests {
+		var buf bytes.Buffer
+		s, _ := NewSpinnerNoColor(Slash)
+		s.Writer = &buf
+		s.SetClock(NewFakeClock(time.Now()))
+		lw := NewLineWriter(s)
+
+		s.StartWithSpeed("test", time.Hour)
+		s.Render()
+		for _, w := range test.writes {
+			lw.Write([]byte(w))
+		}
+
+		if got := buf.String(); got != test.want {
+			t.Errorf("%+v\n - Wrong result, got: %q, want: %q", test, got, test.want)
+		}
+		s.Stop()
+	}
+}
+
+func TestLineWriterFlushOnStop(t *testing.T) {
+	want := "\r- test\r      \rlog\n\r- test\r✔ test\n"
+
+	var buf bytes.Buffer
+	s, _ := NewSpinnerNoColor(Slash)
+	s.Writer = &buf
+	s.SetClock(NewFakeClock(time.Now()))
+	lw := NewLineWriter(s)
+
+	s.StartWithSpeed("test", time.Hour)
+	s.Render()
+	lw.Write([]byte("log"))
+	s.Succeed()
+
+	if got := buf.String(); got != want {
+		t.Errorf("- Wrong result, got: %q, want: %q", got, want)
+	}
+}
+
+func TestLineWriterLongLine(t *testing.T) {
+	var buf bytes.Buffer
+	s, _ := NewSpinnerNoColor(Slash)
+	s.Writer = &buf
+	lw := NewLineWriter(s)
+
+	lw.Write([]byte(strings.Repeat("a", maxLineLength+10)))
+	want := strings.Repeat("a", maxLineLength) + "\n"
+	if got := buf.String(); got != want {
+		t.Errorf("- Wrong result, got: %d bytes, want: %d bytes", len(got), len(want))
+	}
+
+	lw.Close()
+	want += strings.Repeat("a", 10) + "\n"
+	if got := buf.String(); got != want {
+		t.Errorf("- Wrong result, got: %d bytes, want: %d bytes", len(got), len(want))
+	}
+	// Multibyte runes are not split.
+	for _, text := range []string{"a" + strings.Repeat("é", 3000), strings.Repeat("測", 2000), strings.Repeat("😀", 1500)} {
+		buf.Reset()
+		lw := NewLineWriter(s)
+		lw.Write([]byte(text))
+		lw.Close()
+
+		lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+		if len(lines) != 2 {
+			t.Errorf("- Wrong number of lines, got: %d, want: 2", len(lines))
+		}
+		for _, l := range lines {
+			if !utf8.ValidString(l) || len(l) > maxLineLength {
+				t.Errorf("- The lines should be valid UTF-8 and not longer than the max, got: %d bytes, valid: %t", len(l), utf8.ValidString(l))
+			}
+		}
+		if strings.Join(lines, "") != text {
+			t.Errorf("- The lines should have all the text")
+		}
+	}
+}
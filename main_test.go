@@ -0,0 +1,16 @@
+package gospinner
+
+import (
+	"os"
+	"testing"
+)
+
+// TestMain runs the tests without the environment that changes the output of
+// the spinners, so the results don't depend on where they run.
+func TestMain(m *testing.M) {
+	for _, name := range []string{"NO_COLOR", "FORCE_COLOR", "LC_ALL", "LC_CTYPE"} {
+		os.Unsetenv(name)
+	}
+	os.Setenv("LANG", "en_US.UTF-8")
+	os.Exit(m.Run())
+}
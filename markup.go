@@ -0,0 +1,153 @@
+package gospinner
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// markupStyles are the SGR parameters of the text styles of the markup
+var markupStyles = map[string]string{
+	"bold":      "1",
+	"dim":       "2",
+	"italic":    "3",
+	"underline": "4",
+}
+
+// markupClose is the tag that closes the last opened tag
+const markupClose = "[/]"
+
+// sgr returns the SGR parameters of a foreground or background color
+func sgr(c ColorAttr, bg bool) string {
+	attrs := c.attributes()
+	params := make([]string, len(attrs))
+	for i, a := range attrs {
+		params[i] = fmt.Sprint(int(a))
+	}
+	if bg {
+		if len(attrs) == 1 {
+			params[0] = fmt.Sprint(int(attrs[0]) + 10)
+		} else {
+			params[0] = "48"
+		}
+	}
+	return strings.Join(params, ";")
+}
+
+// parseTag returns the SGR parameters of a tag like "bold", "red",
+// "bg:#ff8800" or "bold fg:red", false if it's not a valid tag
+func parseTag(tag string, level colorLevel) (string, bool) {
+	fields := strings.Fields(tag)
+	if len(fields) == 0 {
+		return "", false
+	}
+
+	params := make([]string, 0, len(fields))
+	for _, f := range fields {
+		if p, ok := markupStyles[strings.ToLower(f)]; ok {
+			params = append(params, p)
+			continue
+		}
+		// The numbers of the 256 colors need the prefix so tags like "[1]"
+		// are kept as text.
+		bg, prefixed := false, true
+		switch {
+		case strings.HasPrefix(strings.ToLower(f), "bg:"):
+			bg, f = true, f[3:]
+		case strings.HasPrefix(strings.ToLower(f), "fg:"):
+			f = f[3:]
+		default:
+			prefixed = false
+		}
+		if _, err := strconv.Atoi(f); err == nil && !prefixed {
+			return "", false
+		}
+		c, err := ParseColor(f)
+		if err != nil {
+			return "", false
+		}
+		params = append(params, sgr(c.downgrade(level), bg))
+	}
+	return strings.Join(params, ";"), true
+}
+
+// renderMarkup replaces the markup tags of the text with ANSI sequences, like
+// "[bold]foo[/]@[dim]1.2.3[/]", the closing tag "[/]" closes the last opened
+// one and "[[" is an escaped "[". With plain the tags are removed. The brackets
+// that aren't valid tags are kept as they are.
+func renderMarkup(text string, level colorLevel, plain bool) string {
+	if !strings.Contains(text, "[") {
+		return text
+	}
+
+	var b strings.Builder
+	var open []string
+	for len(text) > 0 {
+		i := strings.IndexByte(text, '[')
+		if i < 0 {
+			b.WriteString(text)
+			break
+		}
+		b.WriteString(text[:i])
+		text = text[i:]
+
+		if strings.HasPrefix(text, "[[") {
+			b.WriteByte('[')
+			text = text[2:]
+			continue
+		}
+
+		end := strings.IndexByte(text, ']')
+		if end < 0 {
+			b.WriteString(text)
+			break
+		}
+
+		if text[:end+1] == markupClose && len(open) > 0 {
+			open = open[:len(open)-1]
+			if !plain {
+				b.WriteString(resetSeq)
+				for _, p := range open {
+					fmt.Fprintf(&b, "\x1b[%sm", p)
+				}
+			}
+			text = text[end+1:]
+			continue
+		}
+
+		p, ok := parseTag(text[1:end], level)
+		if !ok {
+			b.WriteByte('[')
+			text = text[1:]
+			continue
+		}
+		open = append(open, p)
+		if !plain {
+			fmt.Fprintf(&b, "\x1b[%sm", p)
+		}
+		text = text[end+1:]
+	}
+
+	if len(open) > 0 && !plain {
+		b.WriteString(resetSeq)
+	}
+	return b.String()
+}
+
+// EscapeMarkup escapes the text so it's shown as it is on the messages and the
+// frames, use it for text that is not under control, like the output of a
+// command.
+func EscapeMarkup(text string) string {
+	return strings.ReplaceAll(text, "[", "[[")
+}
+
+// stripMarkup removes the markup tags of the text
+func stripMarkup(text string) string {
+	return renderMarkup(text, noColorLevel, true)
+}
+
+// markup renders the markup of the text with the colors of the spinner, the
+// tags are removed when the colors are disabled
+func (s *Spinner) markup(text string) string {
+	return renderMarkup(text, s.colorLevel, s.disableColor)
+}
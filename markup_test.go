@@ -0,0 +1,96 @@
+package gospinner
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRenderMarkup(t *testing.T) {
+	tests := []struct {
+		text  string
+		level colorLevel
+
+		want      string
+		wantPlain string
+	}{
+		{"no markup", basicColorLevel, "no markup", "no markup"},
+		{"[bold]foo[/]@[dim]1.2.3[/]", basicColorLevel, "\x1b[1mfoo\x1b[0m@\x1b[2m1.2.3\x1b[0m", "foo@1.2.3"},
+		{"[italic underline]a[/]", basicColorLevel, "\x1b[3;4ma\x1b[0m", "a"},
+		{"[red]a[bold]b[/]c[/]", basicColorLevel, "\x1b[31ma\x1b[1mb\x1b[0m\x1b[31mc\x1b[0m", "abc"},
+		{"[bg:blue]a", basicColorLevel, "\x1b[44ma\x1b[0m", "a"},
+		{"[fg:#ff8800]a[/]", trueColorLevel, "\x1b[38;2;255;136;0ma\x1b[0m", "a"},
+		{"[bg:#ff8800]a[/]", trueColorLevel, "\x1b[48;2;255;136;0ma\x1b[0m", "a"},
+		{"[fg:208]a[/]", color256Level, "\x1b[38;5;208ma\x1b[0m", "a"},
+		{"[#ff8800]a[/]", basicColorLevel, "\x1b[33ma\x1b[0m", "a"},
+		{"[1/3] [1] [wrong] [/] [", basicColorLevel, "[1/3] [1] [wrong] [/] [", "[1/3] [1] [wrong] [/] ["},
+		{"[[red]a [[[bold]b[/]", basicColorLevel, "[red]a [\x1b[1mb\x1b[0m", "[red]a [b"},
+		{EscapeMarkup("[red]ERROR[/] in [bold] file"), basicColorLevel, "[red]ERROR[/] in [bold] file", "[red]ERROR[/] in [bold] file"},
+	}
+
+	for _, test := range tests {
+		if got := renderMarkup(test.text, test.level, false); got != test.want {
+			t.Errorf("%+v\n - Wrong render, got: %q, want: %q", test, got, test.want)
+		}
+		if got := renderMarkup(test.text, test.level, true); got != test.wantPlain {
+			t.Errorf("%+v\n - Wrong plain render, got: %q, want: %q", test, got, test.wantPlain)
+		}
+	}
+}
+
+func TestMarkupMessages(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	var b bytes.Buffer
+	an, _ := lookupAnimation(Slash)
+	s, _ := NewSpinnerWithAnimation(an, WithWriter(&b), WithoutColor())
+	s.SetWidth(14)
+	s.Start("Installing [bold]foo[/]@[dim]1.2.3[/]")
+	s.SetMessage("[underline]Building[/] bar")
+	s.FinishWithMessage("x", "[bold]Done[/]")
+
+	for _, tag := range []string{"[bold]", "[dim]", "[underline]", "[/]", "\x1b[1m"} {
+		if strings.Contains(b.String(), tag) {
+			t.Errorf("\n - Without color the markup should be stripped, got: %q", b.String())
+		}
+	}
+	if !strings.Contains(b.String(), "x Done\n") {
+		t.Errorf("\n - Wrong closing message, got: %q", b.String())
+	}
+
+	b.Reset()
+	s, _ = NewSpinnerWithAnimation(an, WithWriter(&b), WithoutColor())
+	s.SetRenderMode(LineMode)
+	s.Start("Installing [bold]foo[/]@[dim]1.2.3[/]")
+	s.SetMessage("[underline]Building[/] bar")
+	s.FinishWithMessage("x", "[bold]Done[/]")
+	if want := "Installing foo@1.2.3\nBuilding bar\nx Done\n"; b.String() != want {
+		t.Errorf("\n - Wrong line mode output, got: %q, want: %q", b.String(), want)
+	}
+
+	s, _ = NewSpinnerWithAnimation(an, WithWriter(&b))
+	s.SetWidth(16)
+	s.Start("Installing [bold]foo[/]@[dim]1.2.3[/]")
+	s.Stop()
+	// The markup doesn't take space so the message is truncated on the
+	// visible text.
+	if want := "Installing \x1b[1mf…\x1b[0m"; !strings.HasSuffix(s.frames[0], want) {
+		t.Errorf("\n - Wrong frame, got: %q, want suffix: %q", s.frames[0], want)
+	}
+}
+
+func TestMarkupFrames(t *testing.T) {
+	an, err := NewAnimation([]string{"[bold]a[/]", "bb"}, time.Second)
+	if err != nil {
+		t.Fatalf("\n - Creation shouldn't fail, it did: %s", err)
+	}
+	if an.Width() != 2 {
+		t.Errorf("\n - The markup shouldn't be part of the width, got: %d", an.Width())
+	}
+
+	s, _ := NewSpinnerWithAnimation(an, WithoutColor())
+	s.createFrames()
+	if s.symbols[0] != "a " {
+		t.Errorf("\n - Without color the markup should be stripped, got: %q", s.symbols[0])
+	}
+}
@@ -0,0 +1,152 @@
+package gospinner
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+)
+
+// multiLine is a line of the block of a spinner
+type multiLine struct {
+	spinner *Spinner
+	frame   string
+}
+
+// Multi manages multiple spinners animating at the same time each one on its
+// own line, the finished lines are kept above the running ones.
+type Multi struct {
+	// writer is the target of the printing
+	writer io.Writer
+
+	// lines are the lines of the block that is being animated
+	lines []*multiLine
+
+	// drawn is the number of lines drawn on the last redraw
+	drawn int
+
+	// lineMode writes the finished lines without animating the block
+	lineMode bool
+
+	sync.Mutex
+}
+
+// NewMulti creates a new manager that will write the spinners on w.
+func NewMulti(w io.Writer) *Multi {
+	return &Multi{
+		writer:   w,
+		lineMode: useLineMode(AutoMode, w),
+	}
+}
+
+// NewSpinner creates a new spinner with the common default values that will be
+// rendered by the manager.
+func (m *Multi) NewSpinner(kind AnimationKind) (*Spinner, error) {
+	s, err := NewSpinner(kind)
+	if err != nil {
+		return nil, err
+	}
+	if err := m.Add(s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Add will make the manager render the spinner, it can't be running or be
+// managed by other manager.
+func (m *Multi) Add(s *Spinner) error {
+	s.Lock()
+	defer s.Unlock()
+	if s.running {
+		return errors.New("spinner is already running")
+	}
+	if s.multi != nil {
+		return errors.New("spinner is already managed")
+	}
+	s.multi = m
+	return nil
+}
+
+// update sets the frame of a spinner and redraws the block
+func (m *Multi) update(s *Spinner, frame string) {
+	m.Lock()
+	defer m.Unlock()
+	l := m.line(s)
+	if l == nil {
+		l = &multiLine{spinner: s}
+		m.lines = append(m.lines, l)
+	}
+	l.frame = frame
+	m.redraw(nil)
+}
+
+// finish removes the spinner from the block and writes its final line above the
+// running ones
+func (m *Multi) finish(s *Spinner, final string) {
+	m.Lock()
+	defer m.Unlock()
+	for i, l := range m.lines {
+		if l.spinner == s {
+			m.lines = append(m.lines[:i], m.lines[i+1:]...)
+			break
+		}
+	}
+	if m.lineMode {
+		fmt.Fprintln(m.writer, final)
+		return
+	}
+	m.redraw([]string{final})
+}
+
+// print writes the text above the running lines
+func (m *Multi) print(text string) {
+	m.Lock()
+	defer m.Unlock()
+	if m.lineMode {
+		fmt.Fprint(m.writer, text)
+		return
+	}
+	m.redraw(strings.Split(strings.TrimSuffix(text, "\n"), "\n"))
+}
+
+// isLineMode returns true if the spinners are rendered on line mode
+func (m *Multi) isLineMode() bool {
+	m.Lock()
+	defer m.Unlock()
+	return m.lineMode
+}
+
+// line returns the line of a spinner, nil if the spinner has not been drawn
+func (m *Multi) line(s *Spinner) *multiLine {
+	for _, l := range m.lines {
+		if l.spinner == s {
+			return l
+		}
+	}
+	return nil
+}
+
+// redraw moves the cursor to the start of the block and draws the finished
+// lines followed by the running ones, the lock needs to be held
+func (m *Multi) redraw(finished []string) {
+	var b strings.Builder
+	if m.drawn > 0 {
+		fmt.Fprintf(&b, cursorUpSeq, m.drawn)
+	}
+	for _, f := range finished {
+		fmt.Fprintf(&b, "\r%s%s\n", eraseLineSeq, f)
+	}
+	for _, l := range m.lines {
+		fmt.Fprintf(&b, "\r%s%s\n", eraseLineSeq, l.frame)
+	}
+
+	// Clean the lines that are not used anymore
+	if extra := m.drawn - len(finished) - len(m.lines); extra > 0 {
+		b.WriteString(strings.Repeat(fmt.Sprintf("\r%s\n", eraseLineSeq), extra))
+		fmt.Fprintf(&b, cursorUpSeq, extra)
+	}
+
+	m.drawn = len(m.lines)
+	fmt.Fprint(m.writer, b.String())
+}
@@ -0,0 +1,64 @@
+package gospinner
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestMulti(t *testing.T) {
+	var buf bytes.Buffer
+	m := NewMulti(&buf)
+	clock := NewFakeClock(time.Now())
+
+	a, _ := NewSpinnerNoColor(Ball)
+	b, _ := NewSpinnerNoColor(Ball)
+	for _, s := range []*Spinner{a, b} {
+		if err := m.Add(s); err != nil {
+			t.Fatalf("\n - Add shouldn't fail, it did: %s", err)
+		}
+		s.SetClock(clock)
+	}
+
+	a.StartWithSpeed("a", time.Hour)
+	b.StartWithSpeed("b", time.Hour)
+
+	steps := []struct {
+		action func()
+		want   string
+	}{
+		{func() { a.Render() }, "\r\x1b[2K◐ a\n"},
+		{func() { b.Render() }, "\x1b[1A\r\x1b[2K◐ a\n\r\x1b[2K◐ b\n"},
+		{func() { b.Render() }, "\x1b[2A\r\x1b[2K◐ a\n\r\x1b[2K◓ b\n"},
+		{func() { a.Succeed() }, "\x1b[2A\r\x1b[2K✔ a\n\r\x1b[2K◓ b\n"},
+		{func() { b.Render() }, "\x1b[1A\r\x1b[2K◑ b\n"},
+		{func() { b.Fail() }, "\x1b[1A\r\x1b[2K✖ b\n"},
+	}
+
+	for i, step := range steps {
+		buf.Reset()
+		step.action()
+		if got := buf.String(); got != step.want {
+			t.Errorf("step %d\n - Wrong result, got: %q, want: %q", i, got, step.want)
+		}
+	}
+}
+
+func TestMultiAddError(t *testing.T) {
+	var buf bytes.Buffer
+	m := NewMulti(&buf)
+	m2 := NewMulti(&buf)
+
+	s, _ := m.NewSpinner(Ball)
+	if err := m2.Add(s); err == nil {
+		t.Errorf("\n - Adding a managed spinner should fail, it didn't")
+	}
+
+	s2, _ := NewSpinner(Ball)
+	s2.Writer = &buf
+	s2.Start("test")
+	defer s2.Stop()
+	if err := m.Add(s2); err == nil {
+		t.Errorf("\n - Adding a running spinner should fail, it didn't")
+	}
+}
@@ -0,0 +1,143 @@
+package gospinner
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// normalizeName normalizes a name so "simple-dots", "simple_dots" and
+// "SimpleDots" are the same
+func normalizeName(name string) string {
+	return strings.NewReplacer("-", "", "_", "", " ", "").Replace(strings.ToLower(name))
+}
+
+// String returns the name of the animation kind.
+func (k AnimationKind) String() string {
+	registry.RLock()
+	defer registry.RUnlock()
+	if name, ok := registry.names[k]; ok {
+		return name
+	}
+	return fmt.Sprintf("AnimationKind(%d)", int(k))
+}
+
+// ParseAnimationKind returns the animation kind of a name, the name is case
+// insensitive and ignores dashes and underscores.
+func ParseAnimationKind(name string) (AnimationKind, error) {
+	registry.RLock()
+	defer registry.RUnlock()
+	kind, ok := registry.kinds[normalizeName(name)]
+	if !ok {
+		return 0, fmt.Errorf("unknown animation %q", name)
+	}
+	return kind, nil
+}
+
+// Set sets the animation kind from its name, implements flag.Value.
+func (k *AnimationKind) Set(name string) error {
+	kind, err := ParseAnimationKind(name)
+	if err != nil {
+		return err
+	}
+	*k = kind
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (k AnimationKind) MarshalText() ([]byte, error) {
+	return []byte(k.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (k *AnimationKind) UnmarshalText(text []byte) error {
+	return k.Set(string(text))
+}
+
+// Animations returns all the available animation kinds, the built-in and the
+// registered ones, their names can be obtained with String.
+func Animations() []AnimationKind {
+	registry.RLock()
+	defer registry.RUnlock()
+	kinds := make([]AnimationKind, 0, len(registry.names))
+	for kind := range registry.names {
+		kinds = append(kinds, kind)
+	}
+	sort.Slice(kinds, func(i, j int) bool { return kinds[i] < kinds[j] })
+	return kinds
+}
+
+// colorNames are the names of the colors
+var colorNames = map[ColorAttr]string{
+	FgBlack:     "black",
+	FgRed:       "red",
+	FgGreen:     "green",
+	FgYellow:    "yellow",
+	FgBlue:      "blue",
+	FgMagenta:   "magenta",
+	FgCyan:      "cyan",
+	FgWhite:     "white",
+	FgHiBlack:   "hiBlack",
+	FgHiRed:     "hiRed",
+	FgHiGreen:   "hiGreen",
+	FgHiYellow:  "hiYellow",
+	FgHiBlue:    "hiBlue",
+	FgHiMagenta: "hiMagenta",
+	FgHiCyan:    "hiCyan",
+	FgHiWhite:   "hiWhite",
+}
+
+// String returns the name of the color.
+func (c ColorAttr) String() string {
+	switch {
+	case c&colorRGBFlag != 0:
+		return fmt.Sprintf("#%06x", int(c&colorValue))
+	case c&color256Flag != 0:
+		return strconv.Itoa(int(uint8(c)))
+	}
+	if name, ok := colorNames[c]; ok {
+		return name
+	}
+	return fmt.Sprintf("ColorAttr(%d)", int(c))
+}
+
+// ParseColor returns the color of a name, the name is case insensitive,
+// ignores dashes and underscores and the "fg" prefix is optional. It also
+// accepts hexadecimal codes like "#ff8800" and numbers of the 256 colors
+// palette.
+func ParseColor(name string) (ColorAttr, error) {
+	if strings.HasPrefix(name, "#") {
+		return parseHex(name)
+	}
+	if n, err := strconv.ParseUint(name, 10, 8); err == nil {
+		return Color256(uint8(n)), nil
+	}
+	n := strings.TrimPrefix(normalizeName(name), "fg")
+	for c, cn := range colorNames {
+		if normalizeName(cn) == n {
+			return c, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown color %q", name)
+}
+
+// Set sets the color from its name, implements flag.Value.
+func (c *ColorAttr) Set(name string) error {
+	color, err := ParseColor(name)
+	if err != nil {
+		return err
+	}
+	*c = color
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (c ColorAttr) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (c *ColorAttr) UnmarshalText(text []byte) error {
+	return c.Set(string(text))
+}
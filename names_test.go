@@ -0,0 +1,146 @@
+package gospinner
+
+import (
+	"encoding/json"
+	"flag"
+	"io"
+	"testing"
+	"time"
+)
+
+func TestAnimationKindNames(t *testing.T) {
+	tests := []struct {
+		name string
+
+		want     AnimationKind
+		wantName string
+		wantErr  bool
+	}{
+		{"ball", Ball, "ball", false},
+		{"Dots2", Dots2, "dots2", false},
+		{"simpleDotsScrolling", SimpleDotsScrolling, "simpleDotsScrolling", false},
+		{"simple-dots-scrolling", SimpleDotsScrolling, "simpleDotsScrolling", false},
+		{"PROGRESS_BAR", ProgressBar, "progressBar", false},
+		{"wrong", 0, "", true},
+	}
+
+	for _, test := range tests {
+		got, err := ParseAnimationKind(test.name)
+		if test.wantErr != (err != nil) {
+			t.Errorf("%+v\n - Wrong error, got: %v", test, err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if got != test.want {
+			t.Errorf("%+v\n - Wrong kind, got: %d, want: %d", test, got, test.want)
+		}
+		if got.String() != test.wantName {
+			t.Errorf("%+v\n - Wrong name, got: %s, want: %s", test, got.String(), test.wantName)
+		}
+	}
+
+	if got := AnimationKind(-1).String(); got != "AnimationKind(-1)" {
+		t.Errorf("- Wrong name of unknown kind, got: %s", got)
+	}
+}
+
+func TestColorNames(t *testing.T) {
+	tests := []struct {
+		name string
+
+		want     ColorAttr
+		wantName string
+		wantErr  bool
+	}{
+		{"red", FgRed, "red", false},
+		{"FgHiCyan", FgHiCyan, "hiCyan", false},
+		{"hi-magenta", FgHiMagenta, "hiMagenta", false},
+		{"WHITE", FgWhite, "white", false},
+		{"#ff8800", RGB(255, 136, 0), "#ff8800", false},
+		{"#F80", RGB(255, 136, 0), "#ff8800", false},
+		{"208", Color256(208), "208", false},
+		{"256", 0, "", true},
+		{"#ff88", 0, "", true},
+		{"#gg8800", 0, "", true},
+		{"wrong", 0, "", true},
+	}
+
+	for _, test := range tests {
+		got, err := ParseColor(test.name)
+		if test.wantErr != (err != nil) {
+			t.Errorf("%+v\n - Wrong error, got: %v", test, err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if got != test.want {
+			t.Errorf("%+v\n - Wrong color, got: %d, want: %d", test, got, test.want)
+		}
+		if got.String() != test.wantName {
+			t.Errorf("%+v\n - Wrong name, got: %s, want: %s", test, got.String(), test.wantName)
+		}
+	}
+}
+
+func TestNamesFlag(t *testing.T) {
+	kind := Ball
+	color := FgCyan
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.Var(&kind, "spinner", "spinner style")
+	fs.Var(&color, "color", "spinner color")
+
+	if err := fs.Parse([]string{"-spinner", "pong", "-color", "hiRed"}); err != nil {
+		t.Fatalf("\n - Parse shouldn't fail, it did: %s", err)
+	}
+	if kind != Pong || color != FgHiRed {
+		t.Errorf("- Wrong flags, got: %s %s, want: %s %s", kind, color, Pong, FgHiRed)
+	}
+	if err := fs.Parse([]string{"-spinner", "wrong"}); err == nil {
+		t.Errorf("\n - Parse of a wrong spinner should fail, it didn't")
+	}
+}
+
+func TestNamesJSON(t *testing.T) {
+	type config struct {
+		Spinner AnimationKind `json:"spinner"`
+		Color   ColorAttr     `json:"color"`
+	}
+
+	var cfg config
+	if err := json.Unmarshal([]byte(`{"spinner": "dots", "color": "green"}`), &cfg); err != nil {
+		t.Fatalf("\n - Unmarshal shouldn't fail, it did: %s", err)
+	}
+	if cfg.Spinner != Dots || cfg.Color != FgGreen {
+		t.Errorf("- Wrong config, got: %+v", cfg)
+	}
+
+	b, _ := json.Marshal(cfg)
+	if want := `{"spinner":"dots","color":"green"}`; string(b) != want {
+		t.Errorf("- Wrong JSON, got: %s, want: %s", b, want)
+	}
+}
+
+func TestAnimations(t *testing.T) {
+	an, _ := NewAnimation([]string{"a"}, time.Second)
+	custom := RegisterAnimation("test-animations", an)
+
+	kinds := Animations()
+	if kinds[0] != Ball || kinds[ProgressBar] != ProgressBar {
+		t.Errorf("- Built-in animations should be first and sorted, got: %v", kinds)
+	}
+	found := false
+	for _, k := range kinds {
+		found = found || k == custom
+	}
+	if !found {
+		t.Errorf("- Registered animations should be listed, got: %v", kinds)
+	}
+
+	if kind, err := ParseAnimationKind("test-animations"); err != nil || kind != custom {
+		t.Errorf("- Registered animations should be parsed, got: %v, %v", kind, err)
+	}
+}
@@ -0,0 +1,79 @@
+package gospinner
+
+import (
+	"io"
+)
+
+// Option customizes a spinner on its creation.
+type Option func(s *Spinner)
+
+// WithColor sets the color of the animation.
+func WithColor(color ColorAttr) Option {
+	return func(s *Spinner) {
+		s.setColors(color)
+	}
+}
+
+// WithColorCycle sets a color cycle that changes the color of the animation
+// on each frame.
+func WithColorCycle(cycle ColorCycle) Option {
+	return func(s *Spinner) {
+		s.cycle = cycle
+	}
+}
+
+// WithSucceedColor sets the color of the Succeed finisher symbol.
+func WithSucceedColor(color ColorAttr) Option {
+	return func(s *Spinner) {
+		s.setStatusColor(StatusSuccess, color)
+	}
+}
+
+// WithFailColor sets the color of the Fail finisher symbol.
+func WithFailColor(color ColorAttr) Option {
+	return func(s *Spinner) {
+		s.setStatusColor(StatusFailure, color)
+	}
+}
+
+// WithWarnColor sets the color of the Warn finisher symbol.
+func WithWarnColor(color ColorAttr) Option {
+	return func(s *Spinner) {
+		s.setStatusColor(StatusWarning, color)
+	}
+}
+
+// WithSymbols sets the symbols of the statuses in the set.
+func WithSymbols(set SymbolSet) Option {
+	return func(s *Spinner) {
+		s.setSymbols(set)
+	}
+}
+
+// WithoutColor disables the colors of the spinner.
+func WithoutColor() Option {
+	return func(s *Spinner) {
+		s.disableColors()
+	}
+}
+
+// WithColorMode sets if the spinner uses colors.
+func WithColorMode(mode ColorMode) Option {
+	return func(s *Spinner) {
+		s.colorMode = mode
+	}
+}
+
+// WithCharsetMode sets if the spinner only uses ASCII characters.
+func WithCharsetMode(mode CharsetMode) Option {
+	return func(s *Spinner) {
+		s.charsetMode = mode
+	}
+}
+
+// WithWriter sets the target of the printing.
+func WithWriter(w io.Writer) Option {
+	return func(s *Spinner) {
+		s.Writer = w
+	}
+}
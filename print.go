@@ -0,0 +1,60 @@
+package gospinner
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Write writes p as a line above the animation without breaking it, if p
+// doesn't end with a new line it will be added.
+func (s *Spinner) Write(p []byte) (int, error) {
+	s.Lock()
+	defer s.Unlock()
+	return s.writeAbove(string(p))
+}
+
+// Println formats like fmt.Println and writes the line above the animation.
+func (s *Spinner) Println(a ...interface{}) (int, error) {
+	return s.Write([]byte(fmt.Sprintln(a...)))
+}
+
+// Printf formats like fmt.Printf and writes the line above the animation.
+func (s *Spinner) Printf(format string, a ...interface{}) (int, error) {
+	return s.Write([]byte(fmt.Sprintf(format, a...)))
+}
+
+// writeAbove cleans the current frame, writes the text and renders the current
+// frame again below it, the lock needs to be held
+func (s *Spinner) writeAbove(text string) (int, error) {
+	n := len(text)
+	if !strings.HasSuffix(text, "\n") {
+		text += "\n"
+	}
+
+	if s.multi != nil {
+		s.multi.print(text)
+		return n, nil
+	}
+
+	// Nothing on the line, we can write directly.
+	if s.previousFrame == "" || !s.running || s.lineMode {
+		_, err := fmt.Fprint(s.Writer, text)
+		return n, err
+	}
+
+	_, err := fmt.Fprintf(s.Writer, "%s%s%s", s.clearLine(), text, s.previousFrame)
+	return n, err
+}
+
+// clearLine returns the string that cleans the current frame and leaves the
+// cursor at the start of the line, the lock needs to be held
+func (s *Spinner) clearLine() string {
+	if s.ansi {
+		return s.separator + eraseLineSeq
+	}
+	r := displayWidth(s.previousFrame) - displayWidth(s.separator)
+	if r <= 0 {
+		return s.separator
+	}
+	return s.separator + strings.Repeat(" ", r) + s.separator
+}
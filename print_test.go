@@ -0,0 +1,69 @@
+package gospinner
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestPrint(t *testing.T) {
+	tests := []struct {
+		print func(s *Spinner)
+
+		want string
+	}{
+		{func(s *Spinner) { s.Println("log", 1) }, "\r- test\r      \rlog 1\n\r- test"},
+		{func(s *Spinner) { s.Printf("log %d", 2) }, "\r- test\r      \rlog 2\n\r- test"},
+		{func(s *Spinner) { s.Write([]byte("log 3\n")) }, "\r- test\r      \rlog 3\n\r- test"},
+		{func(s *Spinner) { s.Write([]byte("log 4\nlog 5")) }, "\r- test\r      \rlog 4\nlog 5\n\r- test"},
+	}
+
+	for _, test := range tests {
+		var buf bytes.Buffer
+		s, _ := NewSpinnerNoColor(Slash)
+		s.Writer = &buf
+		s.SetClock(NewFakeClock(time.Now()))
+
+		s.StartWithSpeed("test", time.Hour)
+		s.Render()
+		test.print(s)
+		s.Stop()
+
+		if got := buf.String(); got != test.want {
+			t.Errorf("%+v\n - Wrong result, got: %q, want: %q", test, got, test.want)
+		}
+	}
+}
+
+func TestPrintNotRunning(t *testing.T) {
+	want := "log\n"
+
+	var buf bytes.Buffer
+	s, _ := NewSpinnerNoColor(Ball)
+	s.Writer = &buf
+	s.Println("log")
+
+	if got := buf.String(); got != want {
+		t.Errorf("- Wrong result, got: %q, want: %q", got, want)
+	}
+}
+
+func TestPrintMulti(t *testing.T) {
+	want := "\x1b[1A\r\x1b[2Klog\n\r\x1b[2K◐ a\n"
+
+	var buf bytes.Buffer
+	m := NewMulti(&buf)
+	s, _ := NewSpinnerNoColor(Ball)
+	m.Add(s)
+	s.SetClock(NewFakeClock(time.Now()))
+
+	s.StartWithSpeed("a", time.Hour)
+	s.Render()
+	buf.Reset()
+	s.Println("log")
+	s.Stop()
+
+	if got := buf.String(); got != want {
+		t.Errorf("- Wrong result, got: %q, want: %q", got, want)
+	}
+}
@@ -0,0 +1,95 @@
+package gospinner
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Bar is the representation of a determinate progress, it will be rendered
+// instead of the animation when the spinner has progress.
+type Bar struct {
+	// Width is the number of cells of the bar
+	Width int
+	// Filled and Empty are the symbols of the done and pending cells
+	Filled string
+	Empty  string
+	// ShowETA will show the estimated time to finish based on the progress rate
+	ShowETA bool
+}
+
+// DefaultBar is the bar used by the spinners by default
+var DefaultBar = Bar{Width: 20, Filled: "█", Empty: "▒"}
+
+// progress tracks the determinate progress of the animation
+type progress struct {
+	current int64
+	total   int64
+	start   time.Time
+}
+
+// SetBar sets the bar that will be rendered when the spinner has progress, a
+// negative width is rendered as an empty bar.
+func (s *Spinner) SetBar(b Bar) {
+	if b.Width < 0 {
+		b.Width = 0
+	}
+	s.Lock()
+	s.bar = b
+	s.Unlock()
+}
+
+// SetProgress sets the progress of the animation, the spinner will render a bar
+// with the counts and percentage instead of the animation. A total of zero or
+// less switches back to the animation.
+func (s *Spinner) SetProgress(current, total int64) {
+	s.Lock()
+	defer s.Unlock()
+	if total <= 0 {
+		s.progress = nil
+		return
+	}
+	if current < 0 {
+		current = 0
+	}
+	if current > total {
+		current = total
+	}
+
+	if s.progress == nil {
+		s.progress = &progress{start: s.clock.Now()}
+	}
+	s.progress.current = current
+	s.progress.total = total
+}
+
+// ClearProgress switches back from the progress bar to the animation.
+func (s *Spinner) ClearProgress() {
+	s.SetProgress(0, 0)
+}
+
+// progressParts returns the bar and the message with the counts of the
+// progress at the moment of now, the lock needs to be held
+func (s *Spinner) progressParts(now time.Time) (string, string) {
+	p := s.progress
+	// The counts can be big, like bytes, so they are not multiplied as
+	// integers.
+	current, total := float64(p.current), float64(p.total)
+	filled := int(float64(s.bar.Width) * current / total)
+	filledCell, emptyCell := s.bar.Filled, s.bar.Empty
+	if s.ascii && !isASCII(filledCell+emptyCell) {
+		filledCell, emptyCell = asciiFilled, asciiEmpty
+	}
+	bar := strings.Repeat(filledCell, filled) + strings.Repeat(emptyCell, s.bar.Width-filled)
+	if !s.disableColor && s.color != nil {
+		bar = s.color.SprintfFunc()(bar)
+	}
+
+	message := fmt.Sprintf("%s %d/%d (%d%%)", s.message, p.current, p.total, int(current*100/total))
+	if s.bar.ShowETA && p.current > 0 && p.current < p.total {
+		elapsed := now.Sub(p.start)
+		eta := time.Duration(float64(elapsed) * float64(p.total-p.current) / float64(p.current))
+		message = fmt.Sprintf("%s ETA %s", message, formatElapsed(eta, false))
+	}
+	return bar, message
+}
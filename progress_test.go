@@ -0,0 +1,73 @@
+package gospinner
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRenderProgress(t *testing.T) {
+	tests := []struct {
+		bar      Bar
+		current  int64
+		total    int64
+		duration time.Duration
+
+		want string
+	}{
+		{Bar{Width: 10, Filled: "█", Empty: "▒"}, 0, 120, 0, "|▒▒▒▒▒▒▒▒▒▒ test 0/120 (0%)"},
+		{Bar{Width: 10, Filled: "█", Empty: "▒"}, 37, 120, 0, "|███▒▒▒▒▒▒▒ test 37/120 (30%)"},
+		{Bar{Width: 4, Filled: "=", Empty: " "}, 120, 120, 0, "|==== test 120/120 (100%)"},
+		{Bar{Width: 4, Filled: "=", Empty: " "}, 200, 120, 0, "|==== test 120/120 (100%)"},
+		{Bar{Width: 4, Filled: "=", Empty: " ", ShowETA: true}, 30, 120, 10 * time.Second, "|=    test 30/120 (25%) ETA 30s"},
+		{Bar{Width: 4, Filled: "=", Empty: " ", ShowETA: true}, 120, 120, 10 * time.Second, "|==== test 120/120 (100%)"},
+		{Bar{Width: 4, Filled: "=", Empty: " "}, 29, 100, 0, "|=    test 29/100 (29%)"},
+		{Bar{Width: 4, Filled: "=", Empty: " "}, 1 << 61, 1 << 62, 0, "|==   test 2305843009213693952/4611686018427387904 (50%)"},
+		{Bar{Width: -1, Filled: "=", Empty: " "}, 1, 2, 0, "| test 1/2 (50%)"},
+	}
+
+	for _, test := range tests {
+		var buf bytes.Buffer
+		s, _ := NewSpinnerNoColor(Ball)
+		s.Writer = &buf
+		s.separator = "|"
+		clock := NewFakeClock(time.Now())
+		s.SetClock(clock)
+		s.SetBar(test.bar)
+
+		s.StartWithSpeed("test", time.Hour)
+		s.SetProgress(0, test.total)
+		clock.Advance(test.duration)
+		s.SetProgress(test.current, test.total)
+		s.Render()
+		s.Stop()
+
+		if got := buf.String(); got != test.want {
+			t.Errorf("%+v\n - Wrong result, got: %q, want: %q", test, got, test.want)
+		}
+	}
+}
+
+func TestClearProgress(t *testing.T) {
+	want := "|◐ test|██████████▒▒▒▒▒▒▒▒▒▒ test 1/2 (50%)|◑ test"
+
+	var buf bytes.Buffer
+	s, _ := NewSpinnerNoColor(Ball)
+	s.Writer = &buf
+	s.separator = "|"
+	s.SetClock(NewFakeClock(time.Now()))
+
+	s.StartWithSpeed("test", time.Hour)
+	s.Render()
+	s.SetProgress(1, 2)
+	s.Render()
+	s.ClearProgress()
+	s.Render()
+	s.Stop()
+
+	// The last frame is padded to clean the progress.
+	if got := strings.TrimRight(buf.String(), " "); got != want {
+		t.Errorf("- Wrong result, got: %q, want: %q", got, want)
+	}
+}
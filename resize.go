@@ -0,0 +1,61 @@
+package gospinner
+
+import (
+	"sync"
+)
+
+// resizables tracks the running spinners that use the width of the terminal,
+// they are laid out again when the terminal is resized
+var resizables = struct {
+	spinners map[*Spinner]struct{}
+	stop     func()
+	sync.Mutex
+}{
+	spinners: map[*Spinner]struct{}{},
+}
+
+// widthOf returns the width of the terminal of the writer, can be replaced on
+// tests
+var widthOf = terminalWidth
+
+// watchResize lays out the spinner again when the terminal is resized, the
+// lock needs to be held
+func (s *Spinner) watchResize() {
+	resizables.Lock()
+	defer resizables.Unlock()
+	resizables.spinners[s] = struct{}{}
+	if resizables.stop == nil {
+		resizables.stop = notifyResize(resizeSpinners)
+	}
+}
+
+// unwatchResize stops laying out the spinner on resizes
+func (s *Spinner) unwatchResize() {
+	resizables.Lock()
+	defer resizables.Unlock()
+	delete(resizables.spinners, s)
+	if len(resizables.spinners) == 0 && resizables.stop != nil {
+		resizables.stop()
+		resizables.stop = nil
+	}
+}
+
+// resizeSpinners asks the new width of the terminal to the running spinners
+// and creates their frames again, the next tick will render the new layout
+func resizeSpinners() {
+	resizables.Lock()
+	spinners := make([]*Spinner, 0, len(resizables.spinners))
+	for s := range resizables.spinners {
+		spinners = append(spinners, s)
+	}
+	resizables.Unlock()
+
+	for _, s := range spinners {
+		s.Lock()
+		if s.running && s.fixedWidth == 0 {
+			s.width = widthOf(s.out())
+			s.createFrames()
+		}
+		s.Unlock()
+	}
+}
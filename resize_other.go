@@ -0,0 +1,10 @@
+//go:build !darwin && !dragonfly && !freebsd && !linux && !netbsd && !openbsd && !solaris
+// +build !darwin,!dragonfly,!freebsd,!linux,!netbsd,!openbsd,!solaris
+
+package gospinner
+
+// notifyResize calls fn every time the terminal is resized, not supported on
+// this platform.
+func notifyResize(fn func()) (stop func()) {
+	return func() {}
+}
@@ -0,0 +1,38 @@
+package gospinner
+
+import (
+	"bytes"
+	"io"
+	"testing"
+	"time"
+)
+
+func TestResize(t *testing.T) {
+	width := 20
+	widthOf = func(w io.Writer) int { return width }
+	defer func() { widthOf = terminalWidth }()
+
+	var buf bytes.Buffer
+	s, _ := NewSpinnerNoColor(Slash)
+	s.Writer = &buf
+	s.SetClock(NewFakeClock(time.Now()))
+
+	s.StartWithSpeed("this is a long message", time.Hour)
+	s.Render()
+	width = 12
+	resizeSpinners()
+	s.Render()
+	s.Stop()
+
+	want := "\r- this is a long m…\r\\ this is …        "
+	if got := buf.String(); got != want {
+		t.Errorf("- Wrong result, got: %q, want: %q", got, want)
+	}
+
+	resizables.Lock()
+	_, watched := resizables.spinners[s]
+	resizables.Unlock()
+	if watched {
+		t.Errorf("- Stopped spinner shouldn't be watching resizes, it is")
+	}
+}
@@ -0,0 +1,63 @@
+package gospinner
+
+import (
+	"context"
+	"errors"
+)
+
+// warningError marks an error as a warning for Run
+type warningError struct {
+	err error
+}
+
+func (w *warningError) Error() string { return w.err.Error() }
+func (w *warningError) Unwrap() error { return w.err }
+
+// AsWarning marks an error as a warning, when returned to Run the spinner will
+// be finished with Warn instead of Fail.
+func AsWarning(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &warningError{err: err}
+}
+
+// IsWarning returns true if the error has been marked as a warning.
+func IsWarning(err error) bool {
+	var w *warningError
+	return errors.As(err, &w)
+}
+
+// Run starts the spinner with the message, runs the function and finishes the
+// spinner based on the result: Succeed when there is no error, Warn when the
+// error is a warning and Fail otherwise. If the function panics the spinner is
+// finished with Fail before panicking again.
+func Run[T any](s *Spinner, message string, fn func(ctx context.Context) (T, error)) (T, error) {
+	return RunContext(context.Background(), s, message, fn)
+}
+
+// RunContext is the same as Run but passing the context to the function.
+func RunContext[T any](ctx context.Context, s *Spinner, message string, fn func(ctx context.Context) (T, error)) (T, error) {
+	if err := s.Start(message); err != nil {
+		var zero T
+		return zero, err
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			s.Fail()
+			panic(r)
+		}
+	}()
+
+	res, err := fn(ctx)
+	switch {
+	case err == nil:
+		s.Succeed()
+	case IsWarning(err):
+		s.Warn()
+	default:
+		s.Fail()
+	}
+	return res, err
+}
@@ -0,0 +1,83 @@
+package gospinner
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		result int
+		err    error
+
+		want string
+	}{
+		{1, nil, "✔ test"},
+		{2, errors.New("wanted error"), "✖ test"},
+		{3, AsWarning(errors.New("wanted warning")), "⚠ test"},
+		{4, fmt.Errorf("wrapped: %w", AsWarning(errors.New("wanted warning"))), "⚠ test"},
+	}
+
+	for _, test := range tests {
+		var buf bytes.Buffer
+		s, _ := NewSpinnerNoColor(Ball)
+		s.Writer = &buf
+
+		got, err := Run(s, "test", func(ctx context.Context) (int, error) {
+			return test.result, test.err
+		})
+
+		if got != test.result {
+			t.Errorf("%+v\n - Wrong result, got: %v, want: %v", test, got, test.result)
+		}
+		if err != test.err {
+			t.Errorf("%+v\n - Wrong error, got: %v, want: %v", test, err, test.err)
+		}
+		if !strings.Contains(buf.String(), test.want) {
+			t.Errorf("%+v\n - Wrong frame rendered, got: %v, want: %v", test, buf.String(), test.want)
+		}
+	}
+}
+
+func TestRunPanic(t *testing.T) {
+	var buf bytes.Buffer
+	s, _ := NewSpinnerNoColor(Ball)
+	s.Writer = &buf
+
+	defer func() {
+		if r := recover(); r != "wanted panic" {
+			t.Errorf("- Run should panic again, got: %v", r)
+		}
+		if !strings.Contains(buf.String(), "✖ test") {
+			t.Errorf("Wrong frame rendered, got: %v, want: %v", buf.String(), "✖ test")
+		}
+	}()
+
+	Run(s, "test", func(ctx context.Context) (int, error) {
+		panic("wanted panic")
+	})
+}
+
+func TestRunAlreadyRunning(t *testing.T) {
+	var buf bytes.Buffer
+	s, _ := NewSpinnerNoColor(Ball)
+	s.Writer = &buf
+	s.Start("test")
+	defer s.Stop()
+
+	called := false
+	_, err := Run(s, "test", func(ctx context.Context) (int, error) {
+		called = true
+		return 0, nil
+	})
+	if err == nil {
+		t.Errorf("\n - Run should fail, it didn't")
+	}
+	if called {
+		t.Errorf("\n - Function shouldn't be called, it was")
+	}
+}
@@ -1,17 +1,9 @@
+// Code generated by gensets from sets.json; DO NOT EDIT.
+
 package gospinner
 
 import "time"
 
-// Symbols for the finishing actions
-const (
-	successSymbol = "✔"
-	failureSymbol = "✖"
-	warningSymbol = "⚠"
-)
-
-// AnimationKind represents the kind of the animation
-type AnimationKind int
-
 const (
 	Ball AnimationKind = iota
 	Column
@@ -32,12 +24,6 @@ const (
 	ProgressBar
 )
 
-// Animation represents an animation with frames and speed (recommended)
-type Animation struct {
-	interval time.Duration
-	frames   []string
-}
-
 var animations = map[AnimationKind]Animation{
 	Ball:                Animation{interval: 80 * time.Millisecond, frames: []string{"◐", "◓", "◑", "◒"}},
 	Column:              Animation{interval: 80 * time.Millisecond, frames: []string{"☰", "☱", "☳", "☷", "☶", "☴"}},
@@ -57,3 +43,24 @@ var animations = map[AnimationKind]Animation{
 	Pong:                Animation{interval: 80 * time.Millisecond, frames: []string{"▐⠂       ▌", "▐⠈       ▌", "▐ ⠂      ▌", "▐ ⠠      ▌", "▐  ⡀     ▌", "▐  ⠠     ▌", "▐   ⠂    ▌", "▐   ⠈    ▌", "▐    ⠂   ▌", "▐    ⠠   ▌", "▐     ⡀  ▌", "▐     ⠠  ▌", "▐      ⠂ ▌", "▐      ⠈ ▌", "▐       ⠂▌", "▐       ⠠▌", "▐       ⡀▌", "▐      ⠠ ▌", "▐      ⠂ ▌", "▐     ⠈  ▌", "▐     ⠂  ▌", "▐    ⠠   ▌", "▐    ⡀   ▌", "▐   ⠠    ▌", "▐   ⠂    ▌", "▐  ⠈     ▌", "▐  ⠂     ▌", "▐ ⠠      ▌", "▐ ⡀      ▌", "▐⠠       ▌"}},
 	ProgressBar:         Animation{interval: 120 * time.Millisecond, frames: []string{"▒▒▒▒▒▒▒▒▒▒", "█▒▒▒▒▒▒▒▒▒", "███▒▒▒▒▒▒▒", "█████▒▒▒▒▒", "███████▒▒▒", "██████████"}},
 }
+
+// builtinNames are the names of the built-in animations
+var builtinNames = map[AnimationKind]string{
+	Ball:                "ball",
+	Column:              "column",
+	Slash:               "slash",
+	Square:              "square",
+	Triangle:            "triangle",
+	Dots:                "dots",
+	Dots2:               "dots2",
+	Pipe:                "pipe",
+	SimpleDots:          "simpleDots",
+	SimpleDotsScrolling: "simpleDotsScrolling",
+	GrowVertical:        "growVertical",
+	GrowHorizontal:      "growHorizontal",
+	Arrow:               "arrow",
+	BouncingBar:         "bouncingBar",
+	BouncingBall:        "bouncingBall",
+	Pong:                "pong",
+	ProgressBar:         "progressBar",
+}
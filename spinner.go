@@ -1,32 +1,34 @@
 package gospinner
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io"
 	"os"
-	"strings"
 	"sync"
 	"time"
 )
 
 var (
 	// default colors for the application
-	defaultColor        = FgHiCyan
-	defaultSuccessColor = FgHiGreen
-	defaultFailColor    = FgHiRed
-	defaultWarnColor    = FgHiYellow
+	defaultColor = FgHiCyan
 )
 
-// Spinner is a representation of the animation itself
+// Spinner is a representation of the animation itself, it's safe to use its
+// methods from multiple goroutines
 type Spinner struct {
 
-	// Writer will be the target of the printing
+	// Writer will be the target of the printing, it should be set before
+	// starting the animation
 	Writer io.Writer
 
 	// frames are the frames that will be showed on screen, they are a representation of animation+runes
 	frames []string
 
+	// symbols are the animation characters of each frame already colored
+	symbols []string
+
 	// message is the content wanted to show with the loading animation
 	message string
 
@@ -37,7 +39,47 @@ type Spinner struct {
 	step int
 
 	// ticker is the animation ticker, will set the pace
-	ticker *time.Ticker
+	ticker Ticker
+
+	// clock is the source of time of the animation
+	clock Clock
+
+	// startTime is when the animation was started, elapsed the total duration
+	// of the last finished animation
+	startTime time.Time
+	elapsed   time.Duration
+
+	// showElapsed will show the elapsed time after the message
+	showElapsed bool
+
+	// bar is rendered instead of the animation when there is progress
+	bar      Bar
+	progress *progress
+
+	// lineWriters will be flushed when the animation stops
+	lineWriters []*LineWriter
+
+	// renderMode is how the spinner is rendered, lineMode is set on every
+	// start based on it
+	renderMode RenderMode
+	lineMode   bool
+
+	// width is the width of the line in cells, 0 if unknown, fixedWidth the
+	// width set by the user
+	width      int
+	fixedWidth int
+
+	// clearMode is how the previous frames are cleaned, ansi is set on every
+	// start based on it
+	clearMode ClearMode
+	ansi      bool
+
+	// messages wider than the line are truncated with the ellipsis
+	truncateMode TruncateMode
+	ellipsis     string
+
+	// multi is the manager that renders the spinner when it's part of a block
+	multi *Multi
 
 	// Previous frame is used to clean the screen
 	previousFrame string // TODO use  bytes so we dont allocate new strings always
@@ -45,35 +87,76 @@ type Spinner struct {
 	// running shows the state of the animation
 	running bool
 
-	// Our locker
+	// Our locker, guards the state of the spinner
 	sync.Mutex
 
+	// lifecycle serializes starts, stops and finishes, it's not used by the
+	// animation loop so stops can wait for the loop while holding it
+	lifecycle sync.Mutex
+
 	// Separator will separate the messages each other, by default this should be carriage return
 	separator string
 
 	// colors
-	color        *Color
-	succeedColor *Color
-	failColor    *Color
-	warnColor    *Color
+	color *Color
+
+	// statuses are the finisher symbols of each status
+	statuses map[Status]finisherSymbol
 
-	// disableColor
+	// disableColor is true when the spinner doesn't use colors, it's decided
+	// by the color mode on every start
 	disableColor bool
+	colorMode    ColorMode
+
+	// ascii is true when the spinner only uses ASCII, it's decided by the
+	// charset mode on every start
+	ascii       bool
+	charsetMode CharsetMode
+
+	// colorLevel is the amount of colors of the terminal
+	colorLevel colorLevel
+
+	// cycle changes the color of the animation on each frame
+	cycle ColorCycle
+
+	// quit will be closed when the running animation needs to stop
+	quit chan struct{}
+
+	// done will be closed by the animation loop when it has stopped rendering
+	done chan struct{}
+
+	// finishers used when the context of the animation is done
+	cancelFinisher   Finisher
+	deadlineFinisher Finisher
 }
 
+// Finisher is a function that will finish the animation of a spinner, the
+// spinner finishers can be used as one, for example: (*Spinner).Fail
+type Finisher func(s *Spinner) error
+
 //create is a helper function for all the creators
 func create(kind AnimationKind) (*Spinner, error) {
-	an, ok := animations[kind]
+	an, ok := lookupAnimation(kind)
 	if !ok {
 		return nil, errors.New("Wrong kind of animation")
 	}
-	s := &Spinner{
+	return newSpinner(an), nil
+}
+
+// newSpinner creates a spinner of the animation with the default values
+func newSpinner(an Animation) *Spinner {
+	return &Spinner{
 		animation: an,
 		Writer:    os.Stdout,
 		separator: "\r",
 		Mutex:     sync.Mutex{},
+		clock:     realClock{},
+		bar:       DefaultBar,
+		ellipsis:  defaultEllipsis,
+
+		cancelFinisher:   (*Spinner).Fail,
+		deadlineFinisher: (*Spinner).Warn,
 	}
-	return s, nil
 }
 
 // NewSpinner creates a new spinner with the common default values, this should
@@ -86,14 +169,11 @@ func NewSpinner(kind AnimationKind) (*Spinner, error) {
 // compatible with all the terminals
 func NewSpinnerNoColor(kind AnimationKind) (*Spinner, error) {
 	s, err := NewSpinner(kind)
-	// Disable colors
-	s.disableColor = true
-	s.color.DisableColor()
-	s.succeedColor.DisableColor()
-	s.failColor.DisableColor()
-	s.warnColor.DisableColor()
-
-	return s, err
+	if err != nil {
+		return nil, err
+	}
+	s.disableColors()
+	return s, nil
 }
 
 // NewSpinnerWithColor creates an spinner with a custom color, same as the default
@@ -103,32 +183,100 @@ func NewSpinnerWithColor(kind AnimationKind, color ColorAttr) (*Spinner, error)
 	if err != nil {
 		return nil, err
 	}
+	s.setColors(color)
+	return s, nil
+}
 
-	s.color = newColor(color)
-	s.succeedColor = newColor(defaultSuccessColor)
-	s.failColor = newColor(defaultFailColor)
-	s.warnColor = newColor(defaultWarnColor)
+// NewSpinnerWithAnimation creates a spinner with a custom animation, by default
+// it has the same values as NewSpinner but they can be customized with options.
+func NewSpinnerWithAnimation(anim Animation, opts ...Option) (*Spinner, error) {
+	if err := anim.validate(); err != nil {
+		return nil, err
+	}
+	s := newSpinner(anim)
+	s.setColors(defaultColor)
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s, nil
+}
 
-	s.color.EnableColor()
-	s.succeedColor.EnableColor()
-	s.failColor.EnableColor()
-	s.warnColor.EnableColor()
+// setColors sets the color of the animation and the default finisher colors
+func (s *Spinner) setColors(color ColorAttr) {
+	level := envColorLevel()
+	s.colorLevel = level
+	s.color = newColor(color, level)
+	if s.statuses == nil {
+		s.setSymbols(DefaultSymbols)
+	}
+	s.applyColors(s.colorMode != ColorNever)
+}
 
-	return s, nil
+// disableColors disables the color of the animation and the finishers
+func (s *Spinner) disableColors() {
+	s.colorMode = ColorNever
+	s.applyColors(false)
 }
 
+// applyColors enables or disables the color of the animation and the
+// finishers
+func (s *Spinner) applyColors(enabled bool) {
+	s.disableColor = !enabled
+	colors := []*Color{s.color}
+	for _, symbol := range s.statuses {
+		colors = append(colors, symbol.color)
+	}
+	for _, c := range colors {
+		if enabled {
+			c.EnableColor()
+		} else {
+			c.DisableColor()
+		}
+	}
+}
+
+// createFrames creates the animation frames with the message, the lock needs
+// to be held
 func (s *Spinner) createFrames() {
-	f := make([]string, len(s.animation.frames))
-	for i, c := range s.animation.frames {
-		var symbol = c
+	if s.cycle != nil && !s.disableColor {
+		s.symbols = s.cycleSymbols()
+		s.frames = make([]string, len(s.symbols))
+		for i, symbol := range s.symbols {
+			s.frames[i] = s.layout(symbol, s.message)
+		}
+		return
+	}
+
+	frames := s.animationFrames()
+	f := make([]string, len(frames))
+	symbols := make([]string, len(frames))
+	for i, c := range frames {
+		var symbol = s.markup(c)
 		if !s.disableColor || s.color != nil {
-			symbol = s.color.SprintfFunc()(c)
+			symbol = s.color.SprintfFunc()(symbol)
 		}
-		f[i] = fmt.Sprintf("%s %s", symbol, s.message)
+		symbols[i] = symbol
+		f[i] = s.layout(symbol, s.message)
 	}
 
 	// Set the new animation
 	s.frames = f
+	s.symbols = symbols
+}
+
+// layout returns the line of the symbol and the message, truncating the
+// message if it doesn't fit on the line, the lock needs to be held
+func (s *Spinner) layout(symbol, message string) string {
+	if s.width > 0 {
+		// Leave the last column free so the terminal doesn't wrap the line.
+		max := s.width - 1 - displayWidth(symbol) - 1
+		ellipsis := s.ellipsis
+		if s.ascii && !isASCII(ellipsis) {
+			ellipsis = asciiEllipsis
+		}
+		message = truncate(message, max, ellipsis, s.truncateMode)
+	}
+	return fmt.Sprintf("%s %s", symbol, message)
 }
 
 // Start will animate with the recommended speed, this should be the default
@@ -139,45 +287,226 @@ func (s *Spinner) Start(message string) error {
 
 // StartWithSpeed will start animation witha  custom speed for the spinner
 func (s *Spinner) StartWithSpeed(message string, speed time.Duration) error {
+	return s.StartWithSpeedContext(context.Background(), message, speed)
+}
+
+// StartContext will animate with the recommended speed until the animation is
+// stopped or the context is done, in the latter case the animation will be
+// finished with the cancel or deadline finisher.
+func (s *Spinner) StartContext(ctx context.Context, message string) error {
+	return s.StartWithSpeedContext(ctx, message, s.animation.interval)
+}
+
+// StartWithSpeedContext is the same as StartContext but with a custom speed
+// for the spinner
+func (s *Spinner) StartWithSpeedContext(ctx context.Context, message string, speed time.Duration) error {
+	s.lifecycle.Lock()
+	defer s.lifecycle.Unlock()
 	s.Lock()
 	defer s.Unlock()
 	if s.running {
 		return errors.New("spinner is already running")
 	}
 
-	s.message = message
+	s.width = s.fixedWidth
+	if s.width == 0 {
+		s.width = widthOf(s.out())
+		if s.width > 0 {
+			s.watchResize()
+		}
+	}
+	s.applyColors(useColor(s.colorMode, s.out()))
+	s.ascii = useASCII(s.charsetMode)
+	s.message = s.markup(message)
 	s.createFrames()
-	s.ticker = time.NewTicker(speed)
+	s.ticker = s.clock.NewTicker(speed)
+	if h, ok := s.ticker.(tickHandler); ok {
+		h.waitHandled()
+	}
+	s.quit = make(chan struct{})
+	s.done = make(chan struct{})
+	s.startTime = s.clock.Now()
+	s.progress = nil
+	s.running = true
+
+	s.lineMode = useLineMode(s.renderMode, s.Writer)
+	if s.multi != nil {
+		s.lineMode = s.multi.isLineMode()
+	}
+	if s.lineMode {
+		s.writeLine(s.message)
+	}
+
+	s.ansi = !s.lineMode && s.multi == nil && useANSI(s.clearMode, s.Writer)
+	if s.ansi {
+		s.hideCursor()
+	}
 	// Start the animation in background
-	go func() {
-		s.running = true
+	go s.loop(ctx, s.ticker, s.quit, s.done)
+	return nil
+}
 
-		for range s.ticker.C {
-			s.Render()
+// loop renders the animation on every tick until quit is closed or the context
+// is done, done will be closed when no more frames are going to be rendered.
+func (s *Spinner) loop(ctx context.Context, ticker Ticker, quit, done chan struct{}) {
+	for {
+		select {
+		case t := <-ticker.C():
+			s.Lock()
+			s.render(t)
+			s.Unlock()
+			if h, ok := ticker.(tickHandler); ok {
+				h.handled()
+			}
+		case <-quit:
+			close(done)
+			return
+		case <-ctx.Done():
+			// The finisher will call Stop, and Stop waits for the loop to be done.
+			close(done)
+			s.finishContext(ctx.Err(), quit)
+			return
 		}
-	}()
-	return nil
+	}
+}
+
+// SetShowElapsed sets if the elapsed time of the animation will be shown after
+// the message, the symbol finishers will show the total duration.
+func (s *Spinner) SetShowElapsed(show bool) {
+	s.Lock()
+	s.showElapsed = show
+	s.Unlock()
+}
+
+// Elapsed returns the time the animation has been running, if it's not running
+// it returns the duration of the last animation.
+func (s *Spinner) Elapsed() time.Duration {
+	s.Lock()
+	defer s.Unlock()
+	if s.running {
+		return s.clock.Now().Sub(s.startTime)
+	}
+	return s.elapsed
+}
+
+// SetWidth sets the width of the line in cells, messages wider than it will be
+// truncated. By default 0, the width will be detected from the terminal on
+// every start.
+func (s *Spinner) SetWidth(width int) {
+	s.Lock()
+	s.fixedWidth = width
+	s.Unlock()
+}
+
+// SetTruncation sets how the messages wider than the line are truncated and
+// the ellipsis that replaces the removed part, by default TruncateEnd and "…".
+func (s *Spinner) SetTruncation(mode TruncateMode, ellipsis string) {
+	s.Lock()
+	s.truncateMode = mode
+	s.ellipsis = ellipsis
+	s.createFrames()
+	s.Unlock()
+}
+
+// out returns the writer where the spinner is rendered, the lock needs to be
+// held
+func (s *Spinner) out() io.Writer {
+	if s.multi != nil {
+		return s.multi.writer
+	}
+	return s.Writer
+}
+
+// SetClock sets the clock used by the animation, it will be used from the
+// next start.
+func (s *Spinner) SetClock(c Clock) {
+	s.Lock()
+	s.clock = c
+	s.Unlock()
+}
+
+// SetContextFinishers sets the finishers that will be used when the context of
+// the animation is canceled or its deadline exceeded, by default Fail and Warn.
+// A nil finisher will finish the animation without symbol.
+func (s *Spinner) SetContextFinishers(cancel, deadline Finisher) {
+	s.Lock()
+	s.cancelFinisher = cancel
+	s.deadlineFinisher = deadline
+	s.Unlock()
+}
+
+// finishContext will finish the animation based on the context error, only if
+// the animation that was watching the context is still running.
+func (s *Spinner) finishContext(err error, quit chan struct{}) {
+	s.Lock()
+	if !s.running || s.quit != quit {
+		s.Unlock()
+		return
+	}
+	finisher := s.cancelFinisher
+	if err == context.DeadlineExceeded {
+		finisher = s.deadlineFinisher
+	}
+	s.Unlock()
+
+	if finisher == nil {
+		finisher = (*Spinner).Finish
+	}
+	finisher(s)
 }
 
 // Render will render manually an step
 func (s *Spinner) Render() error {
+	s.Lock()
+	defer s.Unlock()
+	return s.render(s.clock.Now())
+}
+
+// render renders the current step at the moment of now, the lock needs to be
+// held
+func (s *Spinner) render(now time.Time) error {
 	if len(s.frames) == 0 {
 		return errors.New("no frames available to to render")
 	}
 
+	// There is no animation on line mode.
+	if s.lineMode {
+		return nil
+	}
+
 	s.step = s.step % len(s.frames)
-	previousLen := len(s.previousFrame)
-	s.previousFrame = fmt.Sprintf("%s%s", s.separator, s.frames[s.step])
-	newLen := len(s.previousFrame)
+	frame := s.frames[s.step]
+	symbol, message := s.symbols[s.step], s.message
+	if s.progress != nil {
+		symbol, message = s.progressParts(now)
+	}
+	// A tick received just before a stop is still rendered with the elapsed
+	// time, only the spinners never started don't have it.
+	elapsed := s.showElapsed && !s.startTime.IsZero()
+	if elapsed {
+		message = fmt.Sprintf("%s (%s)", message, formatElapsed(now.Sub(s.startTime), false))
+	}
+	if s.progress != nil || elapsed {
+		frame = s.layout(symbol, message)
+	}
 
-	// We need to clean the previous message
-	if previousLen > newLen {
-		r := previousLen - newLen
-		suffix := strings.Repeat(" ", r)
-		s.previousFrame = s.previousFrame + suffix
+	// Spinners of a block are rendered by its manager
+	if s.multi != nil {
+		s.previousFrame = frame
+		s.multi.update(s, frame)
+		s.step++
+		return nil
 	}
 
-	fmt.Fprint(s.Writer, s.previousFrame)
+	// We need to clean the previous message
+	line := fmt.Sprintf("%s%s", s.separator, frame)
+	if s.ansi {
+		s.previousFrame = line
+		fmt.Fprint(s.Writer, s.clearLine()+frame)
+	} else {
+		s.previousFrame = line + padding(s.previousFrame, line)
+		fmt.Fprint(s.Writer, s.previousFrame)
+	}
 	s.step++
 	return nil
 }
@@ -185,73 +514,145 @@ func (s *Spinner) Render() error {
 // SetMessage will set new message on the animation without stoping it
 func (s *Spinner) SetMessage(message string) {
 	s.Lock()
+	defer s.Unlock()
+	message = s.markup(message)
+	if s.running && s.lineMode && message != s.message {
+		s.writeLine(message)
+	}
 	s.message = message
-	s.Unlock()
 	s.createFrames()
 }
 
-// Stop will stop the animation
+// Stop will stop the animation, when it returns the spinner will not write
+// more animation frames.
 func (s *Spinner) Stop() error {
+	s.lifecycle.Lock()
+	defer s.lifecycle.Unlock()
+	return s.stop()
+}
+
+// stop stops the animation and waits for the animation loop, the lifecycle
+// lock needs to be held
+func (s *Spinner) stop() error {
 	s.Lock()
-	defer s.Unlock()
 	if !s.running {
+		s.Unlock()
 		return errors.New("spinner is not running")
 	}
+	lineWriters := make([]*LineWriter, len(s.lineWriters))
+	copy(lineWriters, s.lineWriters)
+	s.Unlock()
+
+	// Flush the pending lines while the animation is still on the line.
+	for _, lw := range lineWriters {
+		lw.Flush()
+	}
+
+	s.Lock()
 	s.ticker.Stop()
+	close(s.quit)
 	s.running = false
+	s.elapsed = s.clock.Now().Sub(s.startTime)
+	done := s.done
+	s.Unlock()
+
+	// Wait until the last frame has been rendered.
+	<-done
+
+	s.Lock()
+	if s.ansi {
+		s.showCursor()
+	}
+	s.Unlock()
+	s.unwatchResize()
 	return nil
 }
 
 // Reset will set the spinner to its initial frame
 func (s *Spinner) Reset() {
-	s.step = 0
-	s.createFrames()
-}
-
-// Succeed will stop the animation with a success symbol where the spinner is
-func (s *Spinner) Succeed() error {
-	return s.FinishWithSymbol(s.succeedColor.SprintfFunc()(successSymbol))
-}
-
-// Fail will stop the animation with a failure symbol where the spinner is
-func (s *Spinner) Fail() error {
-	return s.FinishWithSymbol(s.failColor.SprintfFunc()(failureSymbol))
+	s.Lock()
+	s.reset()
+	s.Unlock()
 }
 
-// Warn will stop the animation with a warning symbol where the spinner is
-func (s *Spinner) Warn() error {
-	return s.FinishWithSymbol(s.warnColor.SprintfFunc()(warningSymbol))
+// reset sets the spinner to its initial frame, the lock needs to be held
+func (s *Spinner) reset() {
+	s.step = 0
+	s.createFrames()
 }
 
 // Finish will stop an write to the next line
 func (s *Spinner) Finish() error {
-	if err := s.Stop(); err != nil {
+	s.lifecycle.Lock()
+	defer s.lifecycle.Unlock()
+	if err := s.stop(); err != nil {
 		return err
 	}
-	s.Reset()
+
+	s.Lock()
+	defer s.Unlock()
+	s.reset()
+	if s.lineMode {
+		return nil
+	}
+	if s.multi != nil {
+		s.multi.finish(s, s.previousFrame)
+		return nil
+	}
 	fmt.Fprint(s.Writer, "\n")
 	return nil
 }
 
 // FinishWithSymbol will finish the animation with a symbol where the spinner is
 func (s *Spinner) FinishWithSymbol(symbol string) error {
-	return s.FinishWithMessage(symbol, s.message)
+	s.lifecycle.Lock()
+	defer s.lifecycle.Unlock()
+	if err := s.stop(); err != nil {
+		return err
+	}
+
+	s.Lock()
+	defer s.Unlock()
+	message := s.message
+	if s.showElapsed {
+		message = fmt.Sprintf("%s (%s)", message, formatElapsed(s.elapsed, true))
+	}
+	s.finishLine(symbol, message)
+	return nil
 }
 
 // FinishWithMessage will finish animation setting a message and a symbol where the spinner was
 func (s *Spinner) FinishWithMessage(symbol, closingMessage string) error {
-	if err := s.Stop(); err != nil {
+	s.lifecycle.Lock()
+	defer s.lifecycle.Unlock()
+	if err := s.stop(); err != nil {
 		return err
 	}
-	s.Reset()
-	previousLen := len(s.previousFrame)
-	finalMsg := fmt.Sprintf("%s %s", symbol, closingMessage)
-	newLen := len(finalMsg)
-	if previousLen > newLen {
-		r := previousLen - newLen
-		suffix := strings.Repeat(" ", r)
-		finalMsg = finalMsg + suffix
-	}
-	fmt.Fprintf(s.Writer, "%s%s\n", s.separator, finalMsg)
+
+	s.Lock()
+	defer s.Unlock()
+	s.finishLine(symbol, s.markup(closingMessage))
 	return nil
 }
+
+// finishLine writes the closing line of a stopped animation, the lock needs
+// to be held
+func (s *Spinner) finishLine(symbol, closingMessage string) {
+	s.reset()
+	finalMsg := s.layout(symbol, closingMessage)
+	if s.lineMode {
+		s.writeLine(finalMsg)
+		return
+	}
+	if s.multi != nil {
+		s.multi.finish(s, finalMsg)
+		return
+	}
+	// We need to clean the previous message
+	if s.ansi {
+		fmt.Fprintf(s.Writer, "%s%s\n", s.clearLine(), finalMsg)
+		return
+	}
+	finalMsg = fmt.Sprintf("%s%s", s.separator, finalMsg)
+	fmt.Fprintf(s.Writer, "%s%s\n", finalMsg, padding(s.previousFrame, finalMsg))
+}
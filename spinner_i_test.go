@@ -23,9 +23,11 @@ func TestIntegrationStartNoColor(t *testing.T) {
 		s, _ := NewSpinnerNoColor(test.kind)
 		s.Writer = &buf
 		s.separator = "|"
+		clock := NewFakeClock(time.Now())
+		s.SetClock(clock)
 
 		s.Start(test.startMessage)
-		time.Sleep(s.animation.interval*time.Duration(len(s.animation.frames)) + 5*time.Millisecond)
+		clock.Advance(s.animation.interval * time.Duration(len(s.animation.frames)))
 		s.Stop()
 
 		got := buf.String()
@@ -51,10 +53,13 @@ func TestIntegrationStartDefault(t *testing.T) {
 		var buf bytes.Buffer
 		s, _ := NewSpinner(test.kind)
 		s.Writer = &buf
+		s.SetColorMode(ColorAlways)
 		s.separator = "|"
+		clock := NewFakeClock(time.Now())
+		s.SetClock(clock)
 
 		s.Start(test.startMessage)
-		time.Sleep(s.animation.interval*time.Duration(len(s.animation.frames)) + 5*time.Millisecond)
+		clock.Advance(s.animation.interval * time.Duration(len(s.animation.frames)))
 		s.Stop()
 
 		got := buf.String()
@@ -81,10 +86,13 @@ func TestIntegrationStartColor(t *testing.T) {
 		var buf bytes.Buffer
 		s, _ := NewSpinnerWithColor(test.kind, test.color)
 		s.Writer = &buf
+		s.SetColorMode(ColorAlways)
 		s.separator = "|"
+		clock := NewFakeClock(time.Now())
+		s.SetClock(clock)
 
 		s.Start(test.startMessage)
-		time.Sleep(s.animation.interval*time.Duration(len(s.animation.frames)) + 5*time.Millisecond)
+		clock.Advance(s.animation.interval * time.Duration(len(s.animation.frames)))
 		s.Stop()
 
 		got := buf.String()
@@ -112,9 +120,11 @@ func TestIntegrationStartWithSpeed(t *testing.T) {
 		s.Writer = &buf
 		s.separator = "|"
 		speed := 20 * time.Millisecond
+		clock := NewFakeClock(time.Now())
+		s.SetClock(clock)
 
 		s.StartWithSpeed(test.startMessage, speed)
-		time.Sleep(speed*time.Duration(len(s.animation.frames)) + 5*time.Millisecond)
+		clock.Advance(speed * time.Duration(len(s.animation.frames)))
 		s.Stop()
 
 		got := buf.String()
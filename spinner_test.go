@@ -2,8 +2,12 @@ package gospinner
 
 import (
 	"bytes"
+	"context"
+	"fmt"
 	"reflect"
+	"runtime"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 )
@@ -290,7 +294,9 @@ func TestFinish(t *testing.T) {
 	s, _ := NewSpinnerNoColor(Ball)
 	s.Writer = &buf
 	s.Start("test")
+	s.Lock()
 	s.step = 5
+	s.Unlock()
 	time.Sleep(10 * time.Millisecond)
 
 	if !s.running {
@@ -320,7 +326,9 @@ func TestFinishWithSymbol(t *testing.T) {
 	s, _ := NewSpinnerNoColor(Ball)
 	s.Writer = &buf
 	s.Start("test")
+	s.Lock()
 	s.step = 5
+	s.Unlock()
 	time.Sleep(10 * time.Millisecond)
 
 	if !s.running {
@@ -347,7 +355,9 @@ func TestFinishWithMessage(t *testing.T) {
 	s, _ := NewSpinnerNoColor(Ball)
 	s.Writer = &buf
 	s.Start(message)
+	s.Lock()
 	s.step = 5
+	s.Unlock()
 	time.Sleep(10 * time.Millisecond)
 
 	if !s.running {
@@ -364,3 +374,168 @@ func TestFinishWithMessage(t *testing.T) {
 
 	}
 }
+
+func TestStartContext(t *testing.T) {
+	tests := []struct {
+		cancel   bool
+		timeout  time.Duration
+		cancelF  Finisher
+		timeoutF Finisher
+
+		want string
+	}{
+		{true, 0, (*Spinner).Fail, (*Spinner).Warn, "✖ test"},
+		{false, 5 * time.Millisecond, (*Spinner).Fail, (*Spinner).Warn, "⚠ test"},
+		{true, 0, (*Spinner).Succeed, (*Spinner).Warn, "✔ test"},
+		{false, 5 * time.Millisecond, (*Spinner).Fail, (*Spinner).Succeed, "✔ test"},
+	}
+
+	for _, test := range tests {
+		var buf bytes.Buffer
+		s, _ := NewSpinnerNoColor(Ball)
+		s.Writer = &buf
+		s.SetContextFinishers(test.cancelF, test.timeoutF)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		if !test.cancel {
+			ctx, cancel = context.WithTimeout(context.Background(), test.timeout)
+		}
+		s.StartContext(ctx, "test")
+		time.Sleep(1 * time.Millisecond)
+		if test.cancel {
+			cancel()
+		}
+		time.Sleep(20 * time.Millisecond)
+		cancel()
+
+		s.Lock()
+		running := s.running
+		got := buf.String()
+		s.Unlock()
+		if running {
+			t.Errorf("%+v\n - Spinner should be stopped, it isn't", test)
+		}
+		if !strings.Contains(got, test.want) {
+			t.Errorf("%+v\n - Wrong frame rendered, got: %v, want: %v", test, got, test.want)
+		}
+	}
+}
+
+func TestStartContextStopped(t *testing.T) {
+	var buf bytes.Buffer
+	s, _ := NewSpinnerNoColor(Ball)
+	s.Writer = &buf
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.StartContext(ctx, "test")
+	time.Sleep(1 * time.Millisecond)
+	s.Succeed()
+	cancel()
+	time.Sleep(5 * time.Millisecond)
+
+	s.Lock()
+	got := buf.String()
+	s.Unlock()
+	if strings.Contains(got, "✖ test") {
+		t.Errorf("- Stopped spinner shouldn't be finished by the context, got: %v", got)
+	}
+}
+
+func TestStopNoGoroutineLeak(t *testing.T) {
+	var buf bytes.Buffer
+	s, _ := NewSpinnerNoColor(Ball)
+	s.Writer = &buf
+	before := runtime.NumGoroutine()
+
+	for i := 0; i < 100; i++ {
+		s.StartWithSpeed("test", 1*time.Millisecond)
+		s.Stop()
+	}
+
+	// Give some time to the runtime to clean the goroutines.
+	time.Sleep(10 * time.Millisecond)
+	after := runtime.NumGoroutine()
+	if after > before {
+		t.Errorf("- Goroutines leaked after stopping the spinner, before: %d, after: %d", before, after)
+	}
+}
+
+func TestStopNoWritesAfterStop(t *testing.T) {
+	var buf bytes.Buffer
+	s, _ := NewSpinnerNoColor(Ball)
+	s.Writer = &buf
+
+	s.StartWithSpeed("test", 1*time.Millisecond)
+	time.Sleep(5 * time.Millisecond)
+	s.Stop()
+
+	got := buf.String()
+	time.Sleep(5 * time.Millisecond)
+	if buf.String() != got {
+		t.Errorf("- Spinner shouldn't write after stopping, got: %v, want: %v", buf.String(), got)
+	}
+}
+
+func TestConcurrentUsage(t *testing.T) {
+	var buf bytes.Buffer
+	s, _ := NewSpinner(Dots)
+	s.Writer = &buf
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				switch (i + j) % 6 {
+				case 0:
+					s.StartWithSpeed("test", 1*time.Millisecond)
+				case 1:
+					s.SetMessage("test")
+				case 2:
+					s.Stop()
+				case 3:
+					s.Render()
+				case 4:
+					s.Reset()
+				case 5:
+					s.Succeed()
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+	s.Stop()
+
+	// Every finished line should end with the message.
+	for _, l := range strings.Split(buf.String(), "\n") {
+		if l != "" && !strings.HasSuffix(strings.TrimRight(l, " "), "test") {
+			t.Errorf("- Wrong line rendered, got: %q", l)
+		}
+	}
+}
+
+func TestConcurrentSetMessage(t *testing.T) {
+	var buf bytes.Buffer
+	s, _ := NewSpinnerNoColor(Ball)
+	s.Writer = &buf
+	s.StartWithSpeed("test", 1*time.Millisecond)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				s.SetMessage(fmt.Sprintf("test %d-%d", i, j))
+			}
+		}(i)
+	}
+	wg.Wait()
+	s.SetMessage("last")
+	s.Succeed()
+
+	if !strings.Contains(buf.String(), "✔ last") {
+		t.Errorf("- Wrong frame rendered, got: %v, want: %v", buf.String(), "✔ last")
+	}
+}
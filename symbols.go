@@ -0,0 +1,138 @@
+package gospinner
+
+import (
+	"fmt"
+)
+
+// Status is the result of the task of a spinner, it selects the symbol of
+// FinishWithStatus. Custom statuses can be added with their symbol to a
+// SymbolSet.
+type Status int
+
+const (
+	// StatusSuccess is the status of Succeed.
+	StatusSuccess Status = iota
+	// StatusFailure is the status of Fail.
+	StatusFailure
+	// StatusWarning is the status of Warn.
+	StatusWarning
+	// StatusInfo is the status of Info.
+	StatusInfo
+	// StatusSkip is the status of Skip.
+	StatusSkip
+)
+
+// Symbol is the symbol of a status with its color, ASCII is the text used
+// when the locale is not UTF-8, if it's empty Text is used.
+type Symbol struct {
+	Text  string
+	Color ColorAttr
+	ASCII string
+}
+
+// SymbolSet are the symbols of the statuses.
+type SymbolSet map[Status]Symbol
+
+// DefaultSymbols are the symbols used by default.
+var DefaultSymbols = SymbolSet{
+	StatusSuccess: {"✔", FgHiGreen, "v"},
+	StatusFailure: {"✖", FgHiRed, "x"},
+	StatusWarning: {"⚠", FgHiYellow, "!"},
+	StatusInfo:    {"ℹ", FgHiBlue, "i"},
+	StatusSkip:    {"↓", FgHiBlack, "-"},
+}
+
+// TextSymbols are symbols with text instead of glyphs, like "[OK]".
+var TextSymbols = SymbolSet{
+	StatusSuccess: {"[OK]", FgHiGreen, ""},
+	StatusFailure: {"[FAIL]", FgHiRed, ""},
+	StatusWarning: {"[WARN]", FgHiYellow, ""},
+	StatusInfo:    {"[INFO]", FgHiBlue, ""},
+	StatusSkip:    {"[SKIP]", FgHiBlack, ""},
+}
+
+// finisherSymbol is a symbol ready to be printed
+type finisherSymbol struct {
+	text  string
+	ascii string
+	color *Color
+}
+
+// setSymbols sets the symbols of the statuses in the set, the other statuses
+// keep their symbols
+func (s *Spinner) setSymbols(set SymbolSet) {
+	if s.statuses == nil {
+		s.statuses = map[Status]finisherSymbol{}
+	}
+	for status, symbol := range set {
+		c := newColor(symbol.Color, s.colorLevel)
+		if s.disableColor {
+			c.DisableColor()
+		}
+		s.statuses[status] = finisherSymbol{text: symbol.Text, ascii: symbol.ASCII, color: c}
+	}
+}
+
+// setStatusColor sets the color of the symbol of a status
+func (s *Spinner) setStatusColor(status Status, color ColorAttr) {
+	symbol := s.statuses[status]
+	s.setSymbols(SymbolSet{status: {Text: symbol.text, Color: color, ASCII: symbol.ascii}})
+}
+
+// SetSymbols sets the symbols of the statuses in the set, the other statuses
+// keep their symbols. For example TextSymbols or a set with custom statuses.
+func (s *Spinner) SetSymbols(set SymbolSet) {
+	s.Lock()
+	s.setSymbols(set)
+	s.Unlock()
+}
+
+// FinishWithStatus will stop the animation with the symbol of the status where
+// the spinner is, if the status doesn't have a symbol it's finished with Finish
+// and an error is returned.
+func (s *Spinner) FinishWithStatus(status Status) error {
+	s.Lock()
+	symbol, ok := s.statuses[status]
+	var text string
+	if ok {
+		text = symbol.text
+		if s.ascii && symbol.ascii != "" {
+			text = symbol.ascii
+		}
+		text = symbol.color.Sprint(text)
+	}
+	s.Unlock()
+
+	if !ok {
+		if err := s.Finish(); err != nil {
+			return err
+		}
+		return fmt.Errorf("status %d doesn't have a symbol", int(status))
+	}
+	return s.FinishWithSymbol(text)
+}
+
+// Succeed will stop the animation with a success symbol where the spinner is
+func (s *Spinner) Succeed() error {
+	return s.FinishWithStatus(StatusSuccess)
+}
+
+// Fail will stop the animation with a failure symbol where the spinner is
+func (s *Spinner) Fail() error {
+	return s.FinishWithStatus(StatusFailure)
+}
+
+// Warn will stop the animation with a warning symbol where the spinner is
+func (s *Spinner) Warn() error {
+	return s.FinishWithStatus(StatusWarning)
+}
+
+// Info will stop the animation with an information symbol where the spinner is
+func (s *Spinner) Info() error {
+	return s.FinishWithStatus(StatusInfo)
+}
+
+// Skip will stop the animation with a skip symbol where the spinner is
+func (s *Spinner) Skip() error {
+	return s.FinishWithStatus(StatusSkip)
+}
@@ -0,0 +1,68 @@
+package gospinner
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestFinishWithStatus(t *testing.T) {
+	const deployed Status = 100
+
+	tests := []struct {
+		name    string
+		set     SymbolSet
+		finish  func(s *Spinner) error
+		noColor bool
+
+		want    string
+		wantErr bool
+	}{
+		{"info", nil, (*Spinner).Info, false, "\r\x1b[94mℹ\x1b[0m test\n", false},
+		{"skip", nil, (*Spinner).Skip, false, "\r\x1b[90m↓\x1b[0m test\n", false},
+		{"success", nil, (*Spinner).Succeed, true, "\r✔ test\n", false},
+		{"text success", TextSymbols, (*Spinner).Succeed, true, "\r[OK] test\n", false},
+		{"text failure", TextSymbols, (*Spinner).Fail, false, "\r\x1b[91m[FAIL]\x1b[0m test\n", false},
+		{"custom", SymbolSet{deployed: {Text: "🚀", Color: FgMagenta}}, func(s *Spinner) error { return s.FinishWithStatus(deployed) }, false, "\r\x1b[35m🚀\x1b[0m test\n", false},
+		{"custom keeps defaults", SymbolSet{deployed: {Text: "🚀", Color: FgMagenta}}, (*Spinner).Warn, true, "\r⚠ test\n", false},
+		{"unknown", nil, func(s *Spinner) error { return s.FinishWithStatus(deployed) }, false, "", true},
+	}
+
+	for _, test := range tests {
+		var b bytes.Buffer
+		an, _ := lookupAnimation(Slash)
+		s, _ := NewSpinnerWithAnimation(an, WithWriter(&b), WithSymbols(test.set))
+		if test.noColor {
+			s.SetColorMode(ColorNever)
+		} else {
+			s.SetColorMode(ColorAlways)
+		}
+		s.SetClearMode(ClearPadding)
+		s.Start("test")
+
+		err := test.finish(s)
+		if test.wantErr != (err != nil) {
+			t.Errorf("%s\n - Wrong error, got: %v", test.name, err)
+		}
+		if err != nil {
+			s.Lock()
+			running := s.running
+			s.Unlock()
+			if running {
+				t.Errorf("%s\n - The spinner should be stopped on error", test.name)
+				s.Stop()
+			}
+			continue
+		}
+		if b.String() != test.want {
+			t.Errorf("%s\n - Wrong finish, got: %q, want: %q", test.name, b.String(), test.want)
+		}
+	}
+}
+
+func TestSetSymbols(t *testing.T) {
+	s, _ := NewSpinner(Slash)
+	s.SetSymbols(SymbolSet{StatusSuccess: {Text: "OK", Color: FgGreen}})
+	if s.statuses[StatusSuccess].text != "OK" || s.statuses[StatusFailure].text != "✖" {
+		t.Errorf("\n - Only the statuses of the set should change, got: %q and %q", s.statuses[StatusSuccess].text, s.statuses[StatusFailure].text)
+	}
+}
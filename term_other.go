@@ -0,0 +1,10 @@
+//go:build !darwin && !dragonfly && !freebsd && !linux && !netbsd && !openbsd && !solaris
+// +build !darwin,!dragonfly,!freebsd,!linux,!netbsd,!openbsd,!solaris
+
+package gospinner
+
+// fdWidth returns the number of columns of the terminal of the file
+// descriptor, not supported on this platform.
+func fdWidth(fd uintptr) int {
+	return 0
+}
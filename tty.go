@@ -0,0 +1,93 @@
+package gospinner
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/mattn/go-isatty"
+)
+
+// RenderMode is the way the spinner is rendered on its writer
+type RenderMode int
+
+const (
+	// AutoMode animates the spinner unless the writer is a file that is not a
+	// terminal, like a pipe on a CI, in that case it uses LineMode.
+	AutoMode RenderMode = iota
+	// AnimatedMode always animates the spinner rewriting its line.
+	AnimatedMode
+	// LineMode writes a line when the spinner starts, when its message changes
+	// and when it finishes, without animation.
+	LineMode
+)
+
+// fder is implemented by the writers backed by a file descriptor, like os.File
+type fder interface {
+	Fd() uintptr
+}
+
+// isTerminal returns true if the writer is a file that is a terminal, false
+// if it's a file that is not a terminal and ok false if it's not a file.
+func isTerminal(w io.Writer) (terminal bool, ok bool) {
+	f, ok := w.(fder)
+	if !ok {
+		return false, false
+	}
+	fd := f.Fd()
+	return isatty.IsTerminal(fd) || isatty.IsCygwinTerminal(fd), true
+}
+
+// useLineMode returns true if the render mode on the writer will be LineMode
+func useLineMode(mode RenderMode, w io.Writer) bool {
+	switch mode {
+	case LineMode:
+		return true
+	case AnimatedMode:
+		return false
+	}
+	terminal, ok := isTerminal(w)
+	return ok && !terminal
+}
+
+// SetRenderMode sets how the spinner will be rendered, by default AutoMode, it
+// will be used from the next start.
+func (s *Spinner) SetRenderMode(mode RenderMode) {
+	s.Lock()
+	s.renderMode = mode
+	s.Unlock()
+}
+
+// SetRenderMode sets how the spinners will be rendered, by default AutoMode.
+func (m *Multi) SetRenderMode(mode RenderMode) {
+	m.Lock()
+	m.lineMode = useLineMode(mode, m.writer)
+	m.Unlock()
+}
+
+// writeLine writes a line in line mode, the lock needs to be held
+func (s *Spinner) writeLine(line string) {
+	if s.multi != nil {
+		s.multi.print(line + "\n")
+		return
+	}
+	fmt.Fprintln(s.Writer, line)
+}
+
+// terminalWidth returns the number of columns of the terminal of the writer,
+// 0 if it's unknown. The COLUMNS environment variable is used when the size
+// can't be asked to the terminal.
+func terminalWidth(w io.Writer) int {
+	terminal, ok := isTerminal(w)
+	if !ok || !terminal {
+		return 0
+	}
+	if width := fdWidth(w.(fder).Fd()); width > 0 {
+		return width
+	}
+	if width, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && width > 0 {
+		return width
+	}
+	return 0
+}
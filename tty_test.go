@@ -0,0 +1,87 @@
+package gospinner
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestUseLineMode(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("\n - Pipe creation shouldn't fail, it did: %s", err)
+	}
+	defer r.Close()
+	defer w.Close()
+
+	tests := []struct {
+		mode   RenderMode
+		writer io.Writer
+
+		want bool
+	}{
+		{AutoMode, w, true},
+		{AutoMode, &bytes.Buffer{}, false},
+		{AnimatedMode, w, false},
+		{LineMode, &bytes.Buffer{}, true},
+	}
+
+	for _, test := range tests {
+		if got := useLineMode(test.mode, test.writer); got != test.want {
+			t.Errorf("%+v\n - Wrong line mode, got: %v, want: %v", test, got, test.want)
+		}
+	}
+}
+
+func TestLineMode(t *testing.T) {
+	want := "first\nlog\nsecond\n✔ second\n"
+
+	var buf bytes.Buffer
+	s, _ := NewSpinnerNoColor(Ball)
+	s.Writer = &buf
+	s.SetRenderMode(LineMode)
+	clock := NewFakeClock(time.Now())
+	s.SetClock(clock)
+
+	s.Start("first")
+	clock.Advance(time.Second)
+	s.Render()
+	s.SetMessage("first")
+	s.Println("log")
+	s.SetMessage("second")
+	s.SetProgress(1, 2)
+	clock.Advance(time.Second)
+	s.Succeed()
+
+	if got := buf.String(); got != want {
+		t.Errorf("- Wrong result, got: %q, want: %q", got, want)
+	}
+}
+
+func TestMultiLineMode(t *testing.T) {
+	want := "a\nb\n✔ a\n✖ b\n"
+
+	var buf bytes.Buffer
+	m := NewMulti(&buf)
+	m.SetRenderMode(LineMode)
+	clock := NewFakeClock(time.Now())
+	a, _ := NewSpinnerNoColor(Ball)
+	b, _ := NewSpinnerNoColor(Ball)
+	for _, s := range []*Spinner{a, b} {
+		m.Add(s)
+		s.SetClock(clock)
+	}
+
+	a.Start("a")
+	b.Start("b")
+	a.Render()
+	b.Render()
+	a.Succeed()
+	b.Fail()
+
+	if got := buf.String(); got != want {
+		t.Errorf("- Wrong result, got: %q, want: %q", got, want)
+	}
+}
@@ -0,0 +1,207 @@
+package gospinner
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// TruncateMode is the way the messages that don't fit on the terminal are
+// truncated
+type TruncateMode int
+
+const (
+	// TruncateEnd removes the end of the message.
+	TruncateEnd TruncateMode = iota
+	// TruncateMiddle removes the middle of the message, useful for file paths.
+	TruncateMiddle
+	// TruncateNone doesn't truncate the message.
+	TruncateNone
+)
+
+// defaultEllipsis is the ellipsis used by default when truncating
+const defaultEllipsis = "…"
+
+// resetSeq resets the ANSI attributes
+const resetSeq = "\x1b[0m"
+
+// wideRanges are the ranges of characters that use two cells on a terminal,
+// the east asian wide and fullwidth characters and the emojis
+var wideRanges = []struct{ lo, hi rune }{
+	{0x1100, 0x115F}, {0x231A, 0x231B}, {0x2329, 0x232A}, {0x23E9, 0x23EC},
+	{0x23F0, 0x23F0}, {0x23F3, 0x23F3}, {0x25FD, 0x25FE}, {0x2614, 0x2615},
+	{0x2648, 0x2653}, {0x267F, 0x267F}, {0x2693, 0x2693}, {0x26A1, 0x26A1},
+	{0x26AA, 0x26AB}, {0x26BD, 0x26BE}, {0x26C4, 0x26C5}, {0x26CE, 0x26CE},
+	{0x26D4, 0x26D4}, {0x26EA, 0x26EA}, {0x26F2, 0x26F3}, {0x26F5, 0x26F5},
+	{0x26FA, 0x26FA}, {0x26FD, 0x26FD}, {0x2705, 0x2705}, {0x270A, 0x270B},
+	{0x2728, 0x2728}, {0x274C, 0x274C}, {0x274E, 0x274E}, {0x2753, 0x2755},
+	{0x2757, 0x2757}, {0x2795, 0x2797}, {0x27B0, 0x27B0}, {0x27BF, 0x27BF},
+	{0x2B1B, 0x2B1C}, {0x2B50, 0x2B50}, {0x2B55, 0x2B55}, {0x2E80, 0x303E},
+	{0x3041, 0x33FF}, {0x3400, 0x4DBF}, {0x4E00, 0x9FFF}, {0xA000, 0xA4CF},
+	{0xA960, 0xA97F}, {0xAC00, 0xD7A3}, {0xF900, 0xFAFF}, {0xFE10, 0xFE19},
+	{0xFE30, 0xFE6F}, {0xFF00, 0xFF60}, {0xFFE0, 0xFFE6}, {0x16FE0, 0x16FE4},
+	{0x17000, 0x18AFF}, {0x1B000, 0x1B2FF}, {0x1F004, 0x1F004}, {0x1F0CF, 0x1F0CF},
+	{0x1F18E, 0x1F18E}, {0x1F191, 0x1F19A}, {0x1F200, 0x1F251}, {0x1F300, 0x1F320},
+	{0x1F32D, 0x1F335}, {0x1F337, 0x1F37C}, {0x1F37E, 0x1F393}, {0x1F3A0, 0x1F3CA},
+	{0x1F3CF, 0x1F3D3}, {0x1F3E0, 0x1F3F0}, {0x1F3F4, 0x1F3F4}, {0x1F3F8, 0x1F43E},
+	{0x1F440, 0x1F440}, {0x1F442, 0x1F4FC}, {0x1F4FF, 0x1F53D}, {0x1F54B, 0x1F54E},
+	{0x1F550, 0x1F567}, {0x1F57A, 0x1F57A}, {0x1F595, 0x1F596}, {0x1F5A4, 0x1F5A4},
+	{0x1F5FB, 0x1F64F}, {0x1F680, 0x1F6C5}, {0x1F6CC, 0x1F6CC}, {0x1F6D0, 0x1F6D2},
+	{0x1F6D5, 0x1F6D7}, {0x1F6EB, 0x1F6EC}, {0x1F6F4, 0x1F6FC}, {0x1F7E0, 0x1F7EB},
+	{0x1F90C, 0x1F93A}, {0x1F93C, 0x1F945}, {0x1F947, 0x1F9FF}, {0x1FA70, 0x1FAFF},
+	{0x20000, 0x2FFFD}, {0x30000, 0x3FFFD},
+}
+
+// runeWidth returns the number of cells a rune uses on a terminal
+func runeWidth(r rune) int {
+	switch {
+	case r < 0x20, r >= 0x7F && r < 0xA0:
+		return 0
+	case unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf):
+		return 0
+	}
+
+	// Binary search on the wide ranges.
+	lo, hi := 0, len(wideRanges)-1
+	for lo <= hi {
+		m := (lo + hi) / 2
+		switch {
+		case r < wideRanges[m].lo:
+			hi = m - 1
+		case r > wideRanges[m].hi:
+			lo = m + 1
+		default:
+			return 2
+		}
+	}
+	return 1
+}
+
+// ansiLen returns the length of the ANSI escape sequence at the start of s, 0
+// if s doesn't start with one
+func ansiLen(s string) int {
+	if len(s) < 2 || s[0] != '\x1b' {
+		return 0
+	}
+	switch s[1] {
+	case '[':
+		// CSI sequence, ends with a byte in the 0x40-0x7E range.
+		for i := 2; i < len(s); i++ {
+			if s[i] >= 0x40 && s[i] <= 0x7E {
+				return i + 1
+			}
+		}
+		return len(s)
+	case ']':
+		// OSC sequence, ends with BEL or ST.
+		for i := 2; i < len(s); i++ {
+			if s[i] == '\a' {
+				return i + 1
+			}
+			if s[i] == '\x1b' && i+1 < len(s) && s[i+1] == '\\' {
+				return i + 2
+			}
+		}
+		return len(s)
+	}
+	return 2
+}
+
+// cell is a piece of text with its width on the terminal, ANSI sequences and
+// combining characters have no width
+type cell struct {
+	text  string
+	width int
+	ansi  bool
+}
+
+// splitCells splits the text on cells
+func splitCells(s string) []cell {
+	cells := []cell{}
+	for i := 0; i < len(s); {
+		if n := ansiLen(s[i:]); n > 0 {
+			cells = append(cells, cell{text: s[i : i+n], ansi: true})
+			i += n
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		cells = append(cells, cell{text: s[i : i+size], width: runeWidth(r)})
+		i += size
+	}
+	return cells
+}
+
+// displayWidth returns the number of cells the text uses on a terminal.
+func displayWidth(s string) int {
+	w := 0
+	for _, c := range splitCells(s) {
+		w += c.width
+	}
+	return w
+}
+
+// truncate truncates the text so it fits on max cells, the removed part is
+// replaced by the ellipsis. The ANSI sequences are kept.
+func truncate(s string, max int, ellipsis string, mode TruncateMode) string {
+	if mode == TruncateNone || displayWidth(s) <= max {
+		return s
+	}
+	if max < 0 {
+		max = 0
+	}
+	room := max - displayWidth(ellipsis)
+	if room < 0 {
+		room, ellipsis = max, ""
+	}
+
+	cells := splitCells(s)
+	hasANSI := false
+	for _, c := range cells {
+		hasANSI = hasANSI || c.ansi
+	}
+
+	leftRoom, rightRoom := room, 0
+	if mode == TruncateMiddle {
+		rightRoom = room / 2
+		leftRoom = room - rightRoom
+	}
+
+	// Take the cells from the start.
+	var left strings.Builder
+	w, i := 0, 0
+	for ; i < len(cells); i++ {
+		c := cells[i]
+		if !c.ansi && w+c.width > leftRoom {
+			break
+		}
+		w += c.width
+		left.WriteString(c.text)
+	}
+
+	// Take the cells from the end.
+	right := []string{}
+	w = 0
+	for j := len(cells) - 1; j >= i && rightRoom > 0; j-- {
+		c := cells[j]
+		if !c.ansi && w+c.width > rightRoom {
+			break
+		}
+		w += c.width
+		right = append([]string{c.text}, right...)
+	}
+
+	res := left.String() + ellipsis + strings.Join(right, "")
+	if hasANSI {
+		res += resetSeq
+	}
+	return res
+}
+
+// padding returns the spaces needed to clean the previous text when writing
+// the new one over it
+func padding(previous, current string) string {
+	if r := displayWidth(previous) - displayWidth(current); r > 0 {
+		return strings.Repeat(" ", r)
+	}
+	return ""
+}
@@ -0,0 +1,116 @@
+package gospinner
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestDisplayWidth(t *testing.T) {
+	tests := []struct {
+		text string
+
+		want int
+	}{
+		{"", 0},
+		{"test", 4},
+		{"Это тест", 8},
+		{"दुई", 2},
+		{"测试", 4},
+		{"テスト", 6},
+		{"🚀 go", 5},
+		{"é", 1},
+		{"\x1b[96m◐\x1b[0m test", 6},
+		{"\x1b]8;;http://example.com\x1b\\link\x1b]8;;\x1b\\", 4},
+		{"\r", 0},
+	}
+
+	for _, test := range tests {
+		if got := displayWidth(test.text); got != test.want {
+			t.Errorf("%+v\n - Wrong width, got: %d, want: %d", test, got, test.want)
+		}
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		text     string
+		max      int
+		ellipsis string
+		mode     TruncateMode
+
+		want string
+	}{
+		{"test", 4, "…", TruncateEnd, "test"},
+		{"long test", 6, "…", TruncateEnd, "long …"},
+		{"long test", 6, "...", TruncateEnd, "lon..."},
+		{"long test", 6, "…", TruncateNone, "long test"},
+		{"/usr/local/bin/gospinner", 12, "…", TruncateMiddle, "/usr/l…inner"},
+		{"/usr/local/bin/gospinner", 11, "…", TruncateMiddle, "/usr/…inner"},
+		{"测试测试", 5, "…", TruncateEnd, "测试…"},
+		{"测试测试", 6, "…", TruncateEnd, "测试…"},
+		{"\x1b[1mlong\x1b[0m test", 6, "…", TruncateEnd, "\x1b[1mlong\x1b[0m …\x1b[0m"},
+		{"long test", 0, "…", TruncateEnd, ""},
+		{"long test", 2, "...", TruncateEnd, "lo"},
+	}
+
+	for _, test := range tests {
+		if got := truncate(test.text, test.max, test.ellipsis, test.mode); got != test.want {
+			t.Errorf("%+v\n - Wrong result, got: %q, want: %q", test, got, test.want)
+		}
+	}
+}
+
+func TestRenderTruncate(t *testing.T) {
+	tests := []struct {
+		message  string
+		width    int
+		mode     TruncateMode
+		ellipsis string
+
+		want string
+	}{
+		{"short", 20, TruncateEnd, "…", "\r- short"},
+		{"this is a long message", 12, TruncateEnd, "…", "\r- this is …"},
+		{"/usr/local/bin/gospinner", 12, TruncateMiddle, "…", "\r- /usr…nner"},
+		{"this is a long message", 12, TruncateNone, "…", "\r- this is a long message"},
+		{"this is a long message", 12, TruncateEnd, "...", "\r- this i..."},
+	}
+
+	for _, test := range tests {
+		var buf bytes.Buffer
+		s, _ := NewSpinnerNoColor(Slash)
+		s.Writer = &buf
+		s.SetClock(NewFakeClock(time.Now()))
+		s.SetWidth(test.width)
+		s.SetTruncation(test.mode, test.ellipsis)
+
+		s.StartWithSpeed(test.message, time.Hour)
+		s.Render()
+		s.Stop()
+
+		if got := buf.String(); got != test.want {
+			t.Errorf("%+v\n - Wrong result, got: %q, want: %q", test, got, test.want)
+		}
+	}
+}
+
+func TestRenderPaddingWideChars(t *testing.T) {
+	want := "\r- 测试测试\r- 测试    \r✔ 测      \n"
+
+	var buf bytes.Buffer
+	s, _ := NewSpinnerNoColor(Slash)
+	s.Writer = &buf
+	s.SetClock(NewFakeClock(time.Now()))
+
+	s.StartWithSpeed("测试测试", time.Hour)
+	s.Render()
+	s.SetMessage("测试")
+	s.Reset()
+	s.Render()
+	s.FinishWithMessage("✔", "测")
+
+	if got := buf.String(); got != want {
+		t.Errorf("- Wrong result, got: %q, want: %q", got, want)
+	}
+}